@@ -0,0 +1,337 @@
+// Package ai implements computer opponents for two player, turn based games
+// of perfect information such as tic-tac-toe.
+package ai
+
+import (
+	"math/rand"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// Move is a position on the board, Z is the layer of three dimensional boards
+type Move struct {
+	X int
+	Y int
+	Z int
+}
+
+// State is a game position the AI can search.  Play and Undo must be exact
+// inverses so the search can walk the game tree in place.
+type State interface {
+	// Player returns the player whose turn it is
+	Player() int
+
+	// Moves returns the legal moves for the player whose turn it is, the
+	// most promising moves first.
+	Moves() []Move
+
+	// Play makes the move for the player whose turn it is
+	Play(move Move) error
+
+	// Undo takes back the last move played
+	Undo() error
+
+	// Outcome reports if the game is over and who won, 0 being a draw
+	Outcome() (over bool, winner int)
+}
+
+// Evaluator is implemented by states which can score a position when the
+// search is cut off before the game is over.  The score is from the point of
+// view of player and must stay within -MaxScore and MaxScore.
+type Evaluator interface {
+	Evaluate(player int) int
+}
+
+// MaxScore bounds the scores of an Evaluator, anything beyond is a decided
+// game.
+const MaxScore = 1 << 20
+
+const winScore = MaxScore * 2
+
+// Level is how well the AI plays
+type Level string
+
+// Available levels
+const (
+	// Random picks any legal move
+	Random Level = "random"
+
+	// Greedy takes a win when it sees one, blocks the opponent from winning
+	// on the next move and otherwise picks the best looking move.
+	Greedy Level = "greedy"
+
+	// Imperfect plays like Perfect but blunders into a random move at the
+	// BlunderRate.
+	Imperfect Level = "imperfect"
+
+	// Perfect searches the game tree with minimax and alpha-beta pruning.
+	Perfect Level = "perfect"
+)
+
+// ErrNoMoves is returned when there are no legal moves left to make
+var ErrNoMoves = errors.New("no moves available")
+
+// ParseLevel returns the level named by s
+func ParseLevel(s string) (Level, error) {
+	switch level := Level(s); level {
+	case Random, Greedy, Imperfect, Perfect:
+		return level, nil
+	}
+
+	return "", errors.Errorf("unknown difficulty: %s", s)
+}
+
+// Player is a computer opponent.  It is not safe for concurrent use.
+type Player struct {
+	Level Level
+
+	// BlunderRate is the chance, between 0 and 1, an Imperfect player makes a
+	// random move.
+	BlunderRate float64
+
+	// MaxDepth limits how many moves ahead the search looks, 0 searches until
+	// the game is over.
+	MaxDepth int
+
+	rand *rand.Rand
+}
+
+// NewPlayer creates a player at the given level
+func NewPlayer(level Level) *Player {
+	return &Player{
+		Level: level,
+		rand:  rand.New(rand.NewSource(time.Now().UnixNano())),
+	}
+}
+
+// Move decides on the next move for the player whose turn it is.  The state
+// is searched in place and left as it was found.
+func (p *Player) Move(state State) (Move, error) {
+	if over, _ := state.Outcome(); over {
+		return Move{}, ErrNoMoves
+	}
+
+	moves := state.Moves()
+	if len(moves) == 0 {
+		return Move{}, ErrNoMoves
+	}
+
+	if p.rand == nil {
+		p.rand = rand.New(rand.NewSource(time.Now().UnixNano()))
+	}
+
+	switch p.Level {
+	case Random:
+		return p.randomMove(moves), nil
+	case Greedy:
+		return p.greedyMove(state, moves)
+	case Imperfect:
+		if p.rand.Float64() < p.BlunderRate {
+			return p.randomMove(moves), nil
+		}
+		return p.bestMove(state, moves)
+	case Perfect:
+		return p.bestMove(state, moves)
+	}
+
+	return Move{}, errors.Errorf("unknown difficulty: %s", p.Level)
+}
+
+func (p *Player) randomMove(moves []Move) Move {
+	return moves[p.rand.Intn(len(moves))]
+}
+
+// greedyMove looks a single move ahead for the player and the opponent.
+func (p *Player) greedyMove(state State, moves []Move) (Move, error) {
+	player := state.Player()
+
+	var safe []Move
+
+	for _, move := range moves {
+		won, threatened, err := p.lookAhead(state, move)
+		if err != nil {
+			return Move{}, err
+		}
+
+		if won {
+			return move, nil
+		}
+
+		if !threatened {
+			safe = append(safe, move)
+		}
+	}
+
+	if len(safe) == 0 {
+		safe = moves
+	}
+
+	evaluator, ok := state.(Evaluator)
+	if !ok {
+		return p.randomMove(safe), nil
+	}
+
+	best, bestScore := safe[0], -winScore
+
+	for _, move := range safe {
+		if err := state.Play(move); err != nil {
+			return Move{}, errors.Wrap(err, "failed to play move")
+		}
+
+		score := evaluator.Evaluate(player)
+
+		if err := state.Undo(); err != nil {
+			return Move{}, errors.Wrap(err, "failed to undo move")
+		}
+
+		if score > bestScore {
+			best, bestScore = move, score
+		}
+	}
+
+	return best, nil
+}
+
+// lookAhead plays the move and reports if it wins the game or hands the
+// opponent a winning reply, or loses the game outright as in games where
+// completing a line loses.
+func (p *Player) lookAhead(state State, move Move) (won bool, threatened bool, err error) {
+	player := state.Player()
+
+	if err := state.Play(move); err != nil {
+		return false, false, errors.Wrap(err, "failed to play move")
+	}
+
+	defer func() {
+		if undoErr := state.Undo(); undoErr != nil && err == nil {
+			err = errors.Wrap(undoErr, "failed to undo move")
+		}
+	}()
+
+	if over, winner := state.Outcome(); over {
+		return winner == player, winner != 0 && winner != player, nil
+	}
+
+	threatened, err = p.hasWinningMove(state)
+
+	return false, threatened, err
+}
+
+// hasWinningMove determines if the player whose turn it is can win with
+// their next move.
+func (p *Player) hasWinningMove(state State) (bool, error) {
+	player := state.Player()
+
+	for _, move := range state.Moves() {
+		if err := state.Play(move); err != nil {
+			return false, errors.Wrap(err, "failed to play move")
+		}
+
+		over, winner := state.Outcome()
+
+		if err := state.Undo(); err != nil {
+			return false, errors.Wrap(err, "failed to undo move")
+		}
+
+		if over && winner == player {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// bestMove searches for the move with the best minimax score.  Moves that
+// score equally are picked between at random.
+func (p *Player) bestMove(state State, moves []Move) (Move, error) {
+	player := state.Player()
+
+	shuffled := make([]Move, len(moves))
+	for i, j := range p.rand.Perm(len(moves)) {
+		shuffled[i] = moves[j]
+	}
+
+	best, alpha := shuffled[0], -winScore-1
+
+	for _, move := range shuffled {
+		if err := state.Play(move); err != nil {
+			return Move{}, errors.Wrap(err, "failed to play move")
+		}
+
+		score, err := p.search(state, player, 1, alpha, winScore+1)
+
+		if err := state.Undo(); err != nil {
+			return Move{}, errors.Wrap(err, "failed to undo move")
+		}
+
+		if err != nil {
+			return Move{}, err
+		}
+
+		if score > alpha {
+			best, alpha = move, score
+		}
+	}
+
+	return best, nil
+}
+
+// search scores the state from the point of view of mover, the player who
+// made the last move, assuming the opponent replies with the move which is
+// worst for mover.  Wins are scored higher the sooner they happen so the
+// search never dawdles over a won game.
+func (p *Player) search(state State, mover, depth, alpha, beta int) (int, error) {
+	if over, winner := state.Outcome(); over {
+		switch winner {
+		case 0:
+			return 0, nil
+		case mover:
+			return winScore - depth, nil
+		default:
+			return -winScore + depth, nil
+		}
+	}
+
+	if p.MaxDepth > 0 && depth >= p.MaxDepth {
+		if evaluator, ok := state.(Evaluator); ok {
+			return evaluator.Evaluate(mover), nil
+		}
+
+		return 0, nil
+	}
+
+	opponent := state.Player()
+	worst := winScore + 1
+
+	for _, move := range state.Moves() {
+		if err := state.Play(move); err != nil {
+			return 0, errors.Wrap(err, "failed to play move")
+		}
+
+		score, err := p.search(state, opponent, depth+1, -beta, -alpha)
+		score = -score
+
+		if err := state.Undo(); err != nil {
+			return 0, errors.Wrap(err, "failed to undo move")
+		}
+
+		if err != nil {
+			return 0, err
+		}
+
+		if score < worst {
+			worst = score
+		}
+
+		if score < beta {
+			beta = score
+		}
+
+		if alpha >= beta {
+			break
+		}
+	}
+
+	return worst, nil
+}
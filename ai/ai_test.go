@@ -0,0 +1,243 @@
+package ai
+
+import (
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestParseLevel(t *testing.T) {
+	for _, name := range []string{"random", "greedy", "imperfect", "perfect"} {
+		if level, err := ParseLevel(name); err != nil || Level(name) != level {
+			t.Errorf("unexpected level: %s: %v", level, err)
+		}
+	}
+
+	if _, err := ParseLevel("godlike"); err == nil || "unknown difficulty: godlike" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestPlayer_Move_NoMoves(t *testing.T) {
+	state := testNewState(1, 1, 1, 2, 2, 1, 1, 2, 2)
+
+	if _, err := NewPlayer(Random).Move(state); ErrNoMoves != err {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestPlayer_Move_TakesWin(t *testing.T) {
+	for _, level := range []Level{Greedy, Perfect} {
+		state := testNewState(1, 1, 0, 2, 2, 0, 0, 0, 0)
+
+		move, err := NewPlayer(level).Move(state)
+		if err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+
+		if expected := (Move{X: 0, Y: 2}); expected != move {
+			t.Errorf("%s> unexpected move: %#v", level, move)
+		}
+	}
+}
+
+func TestPlayer_Move_BlocksWin(t *testing.T) {
+	for _, level := range []Level{Greedy, Perfect} {
+		state := testNewState(1, 0, 0, 2, 2, 0, 1, 0, 0)
+
+		move, err := NewPlayer(level).Move(state)
+		if err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+
+		if expected := (Move{X: 1, Y: 2}); expected != move {
+			t.Errorf("%s> unexpected move: %#v", level, move)
+		}
+	}
+}
+
+func TestPlayer_Move_LeavesStateUntouched(t *testing.T) {
+	for _, level := range []Level{Random, Greedy, Imperfect, Perfect} {
+		state := testNewState(1, 0, 0, 0, 2, 0, 0, 0, 0)
+		board := state.board
+
+		if _, err := NewPlayer(level).Move(state); err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+
+		if board != state.board || 0 != len(state.history) {
+			t.Errorf("%s> unexpected state: %#v", level, state)
+		}
+	}
+}
+
+func TestPlayer_Perfect_NeverLoses(t *testing.T) {
+	perfect := NewPlayer(Perfect)
+	random := NewPlayer(Random)
+
+	for i := 0; i < 50; i++ {
+		players := map[int]*Player{1: perfect, 2: random}
+		if i%2 == 1 {
+			players = map[int]*Player{1: random, 2: perfect}
+		}
+
+		state := testNewState(0, 0, 0, 0, 0, 0, 0, 0, 0)
+
+		for {
+			if over, _ := state.Outcome(); over {
+				break
+			}
+
+			move, err := players[state.Player()].Move(state)
+			if err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+
+			if err := state.Play(move); err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+		}
+
+		if _, winner := state.Outcome(); winner != 0 && players[winner] != perfect {
+			t.Fatalf("%d> perfect player lost: %#v", i, state.board)
+		}
+	}
+}
+
+func TestPlayer_Perfect_Draws(t *testing.T) {
+	player := NewPlayer(Perfect)
+	state := testNewState(0, 0, 0, 0, 0, 0, 0, 0, 0)
+
+	for {
+		if over, _ := state.Outcome(); over {
+			break
+		}
+
+		move, err := player.Move(state)
+		if err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+
+		_ = state.Play(move)
+	}
+
+	if _, winner := state.Outcome(); 0 != winner {
+		t.Errorf("unexpected winner: %d: %#v", winner, state.board)
+	}
+}
+
+func TestPlayer_Imperfect_AlwaysBlunders(t *testing.T) {
+	player := NewPlayer(Imperfect)
+	player.BlunderRate = 1
+
+	blundered := false
+
+	for i := 0; i < 50 && !blundered; i++ {
+		state := testNewState(1, 1, 0, 2, 2, 0, 0, 0, 0)
+
+		move, err := player.Move(state)
+		if err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+
+		blundered = move != Move{X: 0, Y: 2}
+	}
+
+	if !blundered {
+		t.Error("expected a blunder")
+	}
+}
+
+// testState is a plain 3x3 tic-tac-toe game
+type testState struct {
+	board   [3][3]int
+	history []Move
+}
+
+func testNewState(x0y0, x0y1, x0y2, x1y0, x1y1, x1y2, x2y0, x2y1, x2y2 int) *testState {
+	return &testState{
+		board: [3][3]int{
+			[3]int{x0y0, x0y1, x0y2},
+			[3]int{x1y0, x1y1, x1y2},
+			[3]int{x2y0, x2y1, x2y2},
+		},
+	}
+}
+
+func (s *testState) Player() int {
+	ones, twos := 0, 0
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			switch s.board[x][y] {
+			case 1:
+				ones++
+			case 2:
+				twos++
+			}
+		}
+	}
+
+	if ones > twos {
+		return 2
+	}
+
+	return 1
+}
+
+func (s *testState) Moves() []Move {
+	var moves []Move
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			if s.board[x][y] == 0 {
+				moves = append(moves, Move{X: x, Y: y})
+			}
+		}
+	}
+
+	return moves
+}
+
+func (s *testState) Play(move Move) error {
+	if s.board[move.X][move.Y] != 0 {
+		return errors.New("space already taken")
+	}
+
+	s.board[move.X][move.Y] = s.Player()
+	s.history = append(s.history, move)
+
+	return nil
+}
+
+func (s *testState) Undo() error {
+	if len(s.history) == 0 {
+		return errors.New("nothing to undo")
+	}
+
+	move := s.history[len(s.history)-1]
+	s.history = s.history[:len(s.history)-1]
+	s.board[move.X][move.Y] = 0
+
+	return nil
+}
+
+func (s *testState) Outcome() (bool, int) {
+	lines := [][3][2]int{
+		{{0, 0}, {0, 1}, {0, 2}},
+		{{1, 0}, {1, 1}, {1, 2}},
+		{{2, 0}, {2, 1}, {2, 2}},
+		{{0, 0}, {1, 0}, {2, 0}},
+		{{0, 1}, {1, 1}, {2, 1}},
+		{{0, 2}, {1, 2}, {2, 2}},
+		{{0, 0}, {1, 1}, {2, 2}},
+		{{0, 2}, {1, 1}, {2, 0}},
+	}
+
+	for _, line := range lines {
+		a, b, c := line[0], line[1], line[2]
+		if p := s.board[a[0]][a[1]]; p != 0 && p == s.board[b[0]][b[1]] && p == s.board[c[0]][c[1]] {
+			return true, p
+		}
+	}
+
+	return len(s.Moves()) == 0, 0
+}
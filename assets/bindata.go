@@ -68,7 +68,7 @@ func (fi bindataFileInfo) Sys() interface{} {
 	return nil
 }
 
-var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x00\xff\xcc\x58\x6d\x73\xdb\xb8\x11\xfe\xae\x5f\xb1\xc6\x65\x86\xf2\x54\x14\xfd\xd6\x24\x55\x44\x75\x52\x3b\xcd\xcb\xb5\x97\xd4\xf6\x39\xf1\x78\xfc\x01\x22\x57\x24\x6c\x10\x60\x01\x90\x94\x4e\xa7\xff\xde\x01\x29\xca\x92\x45\x2a\x71\xa6\x37\x17\x7e\x31\xb1\x58\xec\x3e\xbb\xfb\x80\xbb\xf2\x70\xef\xec\xe3\xe9\xe5\xf5\xa7\x37\x10\x9b\x84\x8f\x3a\x43\xfb\x07\x38\x15\x91\x4f\x50\x10\x10\x91\x4b\xd3\xd4\x27\x97\x2c\xb8\x3f\x93\xc1\xfd\xa5\x44\x32\xea\x74\x86\x31\xd2\x70\xd4\x01\x00\x18\x26\x68\x28\x04\x31\x55\x1a\x8d\x4f\x32\x33\x71\x5f\x92\xf5\xad\xd8\x98\xd4\xc5\xff\x66\x2c\xf7\xc9\x17\xf7\xd7\xd7\xee\xa9\x4c\x52\x6a\xd8\x98\x23\x81\x40\x0a\x83\xc2\xf8\xe4\xfd\x1b\x1f\xc3\x08\x37\x4e\x0a\x9a\xa0\x4f\x72\x86\x45\x2a\x95\x59\x53\x2e\x58\x68\x62\x3f\xc4\x9c\x05\xe8\x96\x8b\x1e\x30\xc1\x0c\xa3\xdc\xd5\x01\xe5\xe8\x1f\x5a\x94\xa5\x25\xc3\x0c\xc7\x91\xc5\xef\xda\x00\xdc\x4b\x89\x43\xaf\x12\x2e\x35\x38\x13\xf7\xa0\x90\xfb\x44\x9b\x19\x47\x1d\x23\x1a\x02\xb1\xc2\x89\x4f\x2c\x78\x3d\xf0\xbc\x84\x4e\x83\x50\xf4\xc7\x52\x1a\x6d\x14\x4d\xed\x22\x90\x89\xb7\x12\x78\xc7\xfd\xe3\xfe\x0b\x2f\xd0\xfa\x41\xd6\x4f\x98\xe8\x07\x5a\x13\x60\xc2\x60\xa4\x98\x99\xf9\x44\xc7\xf4\xf8\xe5\x89\xfb\x8f\xab\x6b\xc6\x2e\xde\xff\x13\x7f\x3e\x0c\xdf\x26\x1f\xce\x5f\xdf\xcf\x82\xec\xdd\xeb\x77\xe7\xd1\xf1\xd1\xc7\xe4\xd7\xa0\x28\x5e\x48\x71\x7c\x7e\x1d\x46\x27\x57\xf4\x2f\x9f\x92\x8b\x4b\xfd\x9b\xf7\xf3\xf3\x97\xf9\x38\x7c\x73\x17\x9f\x64\x04\x02\x25\xb5\x96\x8a\x45\x4c\xf8\x84\x0a\x29\x66\x89\xcc\x74\x9d\xc0\x3f\x2c\x28\xd7\xc4\x98\xe0\xae\xd0\xd4\xbb\x99\xfc\xe5\x90\x9d\xeb\xab\x2f\x57\x27\xe2\xec\xe0\x43\x66\xb8\x78\x4b\x35\x3f\xfd\x90\x9d\xbe\xc8\x8a\xbb\x30\xfb\xfc\xb7\x8b\x2b\xf5\xaf\xfc\xfc\x5a\xca\x4f\xe9\xd1\xf8\xf3\x75\x94\x44\x1f\xfe\xf3\xfe\x4b\xc1\xbd\x8b\xf4\x6b\xa1\x95\x01\x55\xef\xf6\x19\xcb\x70\x06\x73\x48\x69\x18\x32\x11\xb9\x46\xa6\x03\x78\x71\x90\x4e\x5f\xc1\xa2\xb3\x52\xea\x07\xc8\x39\xcc\xa1\x24\xcb\x00\x9e\x97\xfb\x31\xb2\x28\x36\xf5\x2a\xa1\x2a\x62\x62\x00\x7f\xb5\x8b\x89\x14\xc6\xd5\xec\x37\x1c\xc0\xf1\x96\x2d\x25\x0b\x57\xa7\x34\x60\x22\x82\xf9\xf2\x5c\xe5\xf7\x68\xa9\x5b\xe2\xf4\x96\x40\x97\xa8\x03\xc5\x52\x03\x66\x96\xa2\x4f\x0c\x4e\x8d\x77\x47\x73\x5a\x49\x09\x68\x15\x3c\xd4\x85\xde\xd1\x69\x3f\x92\x32\xe2\x48\x53\xa6\xcb\x9a\x58\x99\xc7\xd9\x58\x7b\x54\x44\x19\xa7\xea\x4e\x7b\x87\xfd\xe7\xfd\xe3\x7a\x5d\x56\xe4\x4e\x93\xd1\xd0\xab\x8c\x8e\xbe\xc1\xef\x43\x16\x73\xaa\x20\xa2\x09\x82\x0f\x2b\x83\x32\xcc\x38\x76\x9d\xb5\xbb\xef\xf4\xe0\xe6\x76\xff\x21\x15\xf6\x44\xdf\x5e\x4b\x25\x39\x47\xd5\x75\xde\xd2\x04\x4f\x8d\xe2\x56\xd1\x79\xa6\x03\x99\xda\x33\xce\x33\x1b\x5a\xf9\x52\x30\x11\xca\xc2\xe9\xc1\x24\x13\x81\x61\x52\x74\x2b\xad\x1e\x94\x3a\x3d\x58\x6a\xec\xc3\x7c\xe5\xc5\x3e\x95\x56\x5f\x1b\x6a\x2c\xc6\xf9\xe2\x55\xd3\x76\xc8\x34\x1d\x73\x0c\xc1\x87\x09\xe5\x1a\x5f\x75\x36\xb5\xac\x8b\x7e\x84\xa6\xeb\x78\xa5\x25\x67\xbf\x6f\x62\x14\xdd\x0d\x2d\xfb\xac\xd0\x29\xd4\xa9\x14\x1a\x1f\xe3\x69\xc1\x55\xab\xf7\x43\x6a\xe8\xab\xad\x13\x8b\x5e\xbb\xa7\x56\x0f\x55\x42\xfa\x94\xa3\x32\x5d\xe7\x42\x26\x68\x62\x4b\xbd\xb1\x92\xf7\xb8\xe7\xec\x6f\x7b\xd9\x90\xec\x3f\x4e\x42\x85\xf8\x59\x41\x4d\x10\x77\x9d\x12\x79\x89\x3f\xd3\xeb\x65\xc9\x29\x6f\x42\xc4\x26\xd0\xad\x09\xc2\xf4\x19\x4e\x98\xc0\xb0\x54\x6e\xc3\x6f\x4f\xe4\x94\xc3\x9e\x0f\x0e\xe5\x2c\x47\xa7\x4d\x13\x1a\x0b\x69\x54\x86\xdb\x99\xdc\x8e\x73\x5b\xb2\x68\x09\x3d\xa1\xf7\xf8\x6f\x99\xdb\x7a\xad\xc2\x9d\xf6\x60\xd6\x84\xcb\xde\x8b\x44\x86\xc8\x2d\xe9\x1a\x51\x38\x53\x67\x00\xd3\xed\xc2\x96\x7b\x33\x67\x00\xb3\xed\xbd\x45\x67\x4b\x54\x71\x33\xcd\x2c\x37\x13\x99\xdb\x6b\x53\xfa\x6d\x63\x28\x3c\x85\xa5\xf0\x64\xa6\x42\x33\x5b\xe1\x5b\x18\x0b\xdf\xc5\x5a\x68\xac\xe8\xa6\xde\xa2\xb1\x9e\x02\x8b\xb7\xd5\xa7\x6b\x27\x34\x4b\xc4\xbd\x1a\x57\x20\xc5\x84\xa9\xa4\xeb\xbc\x56\x08\x33\x99\x81\xce\x96\x2f\x05\xd3\x31\x18\x09\xda\x50\x65\x80\x82\xc0\xa2\xfc\xce\xfd\xdd\x69\xa5\xb8\x42\x93\x29\xd1\x70\xdd\x5b\xcb\x2c\xb5\xad\xb3\xc0\xa2\xf5\x0b\x04\x7f\x7c\x7d\x61\xd7\x87\xb3\x49\xf9\x47\x24\xc4\xea\xed\x76\xbf\x6e\xbb\xcb\xf6\x37\xf4\xaa\x29\xb5\x33\x2c\x47\x04\x11\xb9\x0f\x9d\xca\x27\x75\xa7\xaa\xa7\x8a\x90\xe5\x10\x70\xaa\xb5\x4f\x04\xcd\xc7\x54\x41\xf5\xc7\x65\x22\x47\xa5\xb1\x5e\x4e\xd8\x14\x43\xdb\xed\xd7\x9a\xe7\xfa\x61\xeb\x83\x32\x81\x6a\x6d\xbf\xd9\x81\x6b\xe1\x6d\xe9\x95\xba\xf4\x91\xe6\x58\x51\x11\xd6\xe3\xdb\x4f\x64\xf4\x19\x79\x20\x13\xb4\x34\x2d\x27\x5b\x62\xfb\x33\xb1\xb3\xed\xde\xd0\xa3\x8f\x1c\x7b\x21\xcb\xd7\xb0\x3e\x2c\x97\xaf\x5b\xf1\xaf\x42\x80\x72\xd6\x73\x75\x2c\x8b\x80\x6a\x24\xa0\x24\x47\x9f\x24\x94\x89\x96\xe8\x95\x2c\x76\xc4\x1d\x48\xee\xea\xc4\x95\x93\x89\x46\xe3\x9e\xc0\x72\x7d\x02\x76\x2e\x71\x03\x14\xa6\x39\x1d\xd6\x84\x88\x5c\x85\x29\x52\xe3\x93\x19\x30\x01\x37\x07\x3d\x38\xec\xc1\xd1\x6d\xc3\x81\xf2\x90\x4e\xa9\x58\x3f\x35\xfd\x96\x53\xe5\xc9\x71\x66\x8c\x14\x35\xe8\xb1\x11\x60\xa7\xc7\xf2\xc7\x50\xc0\x59\x70\x6f\x33\x50\x75\x90\xaa\x6d\x94\x3b\xf5\x0d\xf2\xc9\xea\x2e\xfd\xfe\x3b\x54\xdd\x75\x2c\xa9\x0a\x6f\xa6\xb7\x37\xb3\x5b\x18\xc1\x01\x69\x75\x6d\x9f\xd2\x4b\xe9\x79\xee\x1c\x38\x03\x70\xc6\x46\xb8\x21\x4e\x68\xc6\x8d\x1d\xa1\x0e\x6b\x19\x13\x13\x69\x05\x47\xb5\x40\x67\x41\x80\x5a\x3b\x8b\x9b\x2d\xb7\xbb\xe2\xad\xb2\x65\x94\x14\x91\x75\xae\x0b\x66\x82\xd8\xfe\x6e\x78\x64\xe3\x2b\x26\x36\x92\x5e\x19\x71\x8b\x18\x85\x4f\x0e\xc9\xe8\xcb\xd0\xb3\x5b\xdf\x6b\xe1\x88\x8c\x3e\x7e\xaf\x85\x65\xea\xaa\x72\x8e\xbe\xc5\x8a\x9d\xdc\x6d\x36\x76\x50\xc4\xab\x38\xb2\x83\x7a\x0d\x34\xde\xbc\x88\x0d\xa2\xf5\x0b\x09\xdb\x37\x0b\xd6\x7e\x78\xfc\xff\x6f\xd9\x69\xa6\x14\x0a\x03\x97\x99\x12\x83\x6d\xf0\x6d\x04\x49\x39\x9d\x35\xda\x83\x1d\x7c\xa8\xa1\x96\x88\x2c\x8f\xbf\xc2\x90\x36\x56\x6c\xd8\x59\xd2\x7f\x07\x55\xda\x0b\xfb\xc8\x41\x1d\x5c\x35\x16\x3f\x29\x38\xb4\x1f\xea\x75\x58\x21\x15\x91\x4d\xd0\x67\x26\x04\xaa\xbd\xa7\x87\x19\x2a\x5a\x34\x9b\x3c\x53\xb4\x68\x35\x58\xcb\x7f\x58\xc2\x95\x23\xf8\x4f\x03\x98\xcf\xab\x64\x8b\x2c\xb1\x22\xbd\x58\xfc\x79\x90\x9b\xba\xcf\x76\x3b\xb0\x1f\xdb\x54\xb1\x84\xaa\x59\xf9\x3e\xe6\xb6\xfd\xae\xf5\x87\xe5\x44\xda\xdd\x27\xa3\x5f\xb0\x00\xfb\xde\xfc\xc9\xf8\x6a\x73\x1e\x7a\x76\x78\xb1\x43\x8c\x57\xfd\x87\xee\x7f\x01\x00\x00\xff\xff\x79\x8f\xe3\x6e\xb2\x13\x00\x00")
+var _indexHtml = []byte("\x1f\x8b\x08\x00\x00\x00\x00\x00\x02\xff\xec\x7d\x7d\x73\xdb\x36\xf2\xf0\xff\xfe\x14\x6b\xb6\xbf\x52\x9a\xe8\xc5\x2f\x69\xda\x93\x45\x65\xd2\xe4\xfa\x76\xb9\xba\x97\x97\x26\x19\x9f\x9f\x19\x88\x84\x24\xc4\x24\xa0\x12\xa0\x65\x59\xd5\xf7\x79\xbe\xc7\xf3\xc5\x9e\x59\x80\xa4\x48\x8a\xa4\x28\xdb\xe9\xe5\xee\x77\xed\x4c\x4c\x82\xd8\xc5\x62\xb1\x58\xec\x2e\x16\xd0\xf0\xf0\xc5\xf9\xf3\x37\x1f\x7e\xfd\x2b\xcc\x54\xe0\x8f\x0e\x86\xf8\x07\x7c\xc2\xa7\x8e\x45\xb9\x05\x7c\xda\x25\xf3\xb9\x63\xbd\x61\xee\xd5\x0b\xe1\x5e\xbd\x11\xd4\x1a\x1d\x1c\x0c\x67\x94\x78\xa3\x03\x00\x80\x61\x40\x15\x01\x77\x46\x42\x49\x95\x63\x45\x6a\xd2\xfd\xd6\xca\x7e\x9a\x29\x35\xef\xd2\xdf\x23\x76\xed\x58\xef\xbb\x6f\x9f\x75\x9f\x8b\x60\x4e\x14\x1b\xfb\xd4\x02\x57\x70\x45\xb9\x72\xac\x9f\xfe\xea\x50\x6f\x4a\x73\x90\x9c\x04\xd4\xb1\xae\x19\x5d\xcc\x45\xa8\x32\x95\x17\xcc\x53\x33\xc7\xa3\xd7\xcc\xa5\x5d\xfd\xd2\x01\xc6\x99\x62\xc4\xef\x4a\x97\xf8\xd4\x39\x46\x2a\x01\x00\x86\x8a\x29\x9f\x8e\x90\xfe\x2e\x76\xa0\xfb\x46\xd0\x61\xdf\x14\xc6\x35\x7c\xc6\xaf\x20\xa4\xbe\x63\x49\xb5\xf4\xa9\x9c\x51\xaa\x2c\x98\x85\x74\xe2\x58\x48\xbc\x1c\xf4\xfb\x01\xb9\x71\x3d\xde\x1b\x0b\xa1\xa4\x0a\xc9\x1c\x5f\x5c\x11\xf4\xd3\x82\xfe\x69\xef\xb4\xf7\x4d\xdf\x95\x72\x53\xd6\x0b\x18\xef\xb9\x52\x5a\xc0\xb8\xa2\xd3\x90\xa9\xa5\x63\xc9\x19\x39\xfd\xf6\x71\xf7\xbb\xdf\x3e\x30\xf6\xfa\xa7\xef\xe9\xdf\x8e\xbd\x1f\x82\x9f\x5f\x3d\xbb\x5a\xba\xd1\x8f\xcf\x7e\x7c\x35\x3d\x3d\x39\x0f\xde\xba\x8b\xc5\x37\x82\x9f\xbe\xfa\xe0\x4d\x1f\xff\x46\x1e\xfd\x1a\xbc\x7e\x23\x6f\xfb\x7f\x7b\xf2\xed\xf5\xd8\xfb\xeb\xc7\xd9\xe3\xc8\x02\x37\x14\x52\x8a\x90\x4d\x19\x77\x2c\xc2\x05\x5f\x06\x22\x92\xd6\xe8\x13\x77\xaa\xab\x66\x34\xa0\x75\x5d\x0b\x7f\x5c\x8a\x5f\x8e\xd9\x2b\xf9\xdb\xfb\xdf\x1e\xf3\x17\x47\x3f\x47\xca\xe7\x3f\x10\xe9\x3f\xff\x39\x7a\xfe\x4d\xb4\xf8\xe8\x45\xef\xfe\xf2\xfa\xb7\xf0\xe5\xf5\xab\x0f\x42\xfc\x3a\x3f\x19\xbf\xfb\x30\x0d\xa6\x3f\xff\xe3\xa7\xf7\x0b\xbf\xff\x7a\xbe\xab\x6b\xba\x43\xe6\x19\x00\x60\x2c\xbc\x25\xac\x60\x4e\x3c\x8f\xf1\x69\x57\x89\xf9\x00\xbe\x39\x9a\xdf\x9c\xc1\xfa\x20\xad\xd4\x73\xa9\xef\xc3\x0a\xb4\xb0\x0c\xe0\x89\xfe\x3e\xa3\x6c\x3a\x53\xc9\x5b\x40\xc2\x29\xe3\x03\xf8\x1a\x5f\x26\x82\xab\xae\x64\xb7\x74\x00\xa7\x5b\xb8\x22\x5f\xb1\x80\x28\x5a\xc4\x7a\xfa\x24\x8b\xf5\xf4\x49\x16\xeb\x09\xbe\xc4\x34\x0e\xe0\x28\xd7\xc2\xf1\xb7\xd5\x2d\xc8\x68\xdc\x1d\x0b\x12\x7a\xdd\x10\xd1\xc2\x2a\xc6\x68\x5e\x07\x70\x7c\xd2\x04\x76\x2c\x94\x12\xc1\x06\xd8\xbc\x0f\xe0\x78\x47\xdf\x7a\xc4\x55\xec\x9a\x1a\x2c\xb0\x82\xb1\xb8\xe9\xca\x19\xf1\xc4\x62\x00\x8c\x4b\xaa\xe0\x48\xff\x7f\x32\xbf\x81\x2f\x26\x47\xc4\x7b\x4c\xf3\xe8\x7e\x8f\xc6\xcc\x2d\xf2\xe9\x71\x8e\xfb\x8f\x73\xdc\x3f\xad\xe1\xd3\xc9\x16\xb5\x31\x7a\x9f\x2c\x69\x58\xd2\xbb\xaf\x8b\xf5\x43\xb1\xe8\xca\x39\x71\x19\x9f\x6e\xaa\x6b\x89\xd9\xc6\x3d\x11\x61\xd0\x65\xdc\x67\x9c\x02\xe3\xf3\x48\x15\xc5\xa7\xaa\xb2\xa4\x3e\x75\x33\xb5\x49\xa4\x04\xd6\x06\x00\x18\xf6\x63\xe9\x35\x6f\xd2\x0d\xd9\x5c\x81\x5a\xce\xa9\x63\x29\x7a\xa3\xfa\x1f\xc9\x35\x31\xa5\x16\xc8\xd0\xdd\x4c\x56\xf2\x91\xdc\xf4\xa6\x42\x4c\x7d\x4a\xe6\x4c\xea\x89\x8a\x65\x7d\x9f\x8d\x65\x9f\xf0\x69\xe4\x93\xf0\xa3\xec\x1f\xf7\x9e\xf4\x4e\x93\x77\x3d\x4d\x3f\x4a\x6b\x34\xec\x1b\xa4\xa3\x06\xed\x8e\xd2\x6e\x5d\x93\x10\xa6\x24\xa0\xe0\x40\x8a\x50\x78\x91\x4f\x5b\x76\x66\x41\xb0\x3b\x70\x71\xd9\xde\x30\x03\x21\x7a\xa8\xab\x43\xe1\xfb\x34\x6c\xd9\x3f\x90\x80\x3e\x57\xa1\x8f\x15\xed\x2f\xa5\x2b\xe6\x08\x63\x7f\x89\x5d\xd3\x0f\x0b\xc6\x3d\xb1\xd0\x8f\xa8\x4e\xc2\x6b\x82\x75\x27\x11\x77\x15\x13\xbc\x65\x40\x3a\xa0\x01\x3a\x10\x57\xef\x40\x5a\xb9\x0d\xab\xb4\x75\x00\x00\x03\xd0\x93\x0a\x05\xd9\x81\xd5\xfa\xac\xec\xb3\xc7\x24\x19\xfb\xd4\x03\x07\x26\xc4\x97\xb4\xb4\x92\xeb\x0b\xf7\x4a\x82\x03\x17\xb9\xaf\x00\x00\x2b\xdb\x27\x63\xea\xdb\x03\xb0\x7f\x11\xa0\x2b\x62\x17\xe6\x34\xfc\xbb\xb8\xa6\xf6\x00\x8e\x3a\x60\x2b\xa1\x88\x1f\x3f\x33\xee\x86\x34\xa0\x5c\xe1\xfb\xba\x53\x87\xf0\xf4\x08\x24\x75\x05\xf7\x24\x10\x08\x10\x5d\x0e\xf3\xe9\x3d\x50\x9f\x40\xc0\x78\xa4\xa8\x84\x47\x70\x1c\xb7\x52\x4d\xf7\xf1\x49\x11\xfd\x71\x3d\xfa\xaf\x13\xf4\xd5\x38\x4f\x8f\x76\x91\x7c\x59\x3a\x16\x92\x2a\xc5\xf8\x54\xe2\x90\xda\x61\xe4\x53\x89\x0d\x4a\x45\xb8\x47\x42\xdd\x87\x80\x49\x1a\x62\x73\x7a\x40\x3b\x60\xeb\x29\x88\x4d\x76\xc0\x36\x0a\x27\x7e\x59\x30\xfe\x92\xf2\x69\xfa\x31\x10\x1e\xc2\xd9\xb3\x28\x20\x1c\x51\x79\x6c\x32\x61\x6e\xe4\xab\x25\x16\xcf\x69\x38\xa1\xae\xc2\x0f\x66\xa0\x07\x79\x01\xb9\x38\xba\x5c\x9f\x1d\x94\x11\x1d\x12\x3e\xa5\xe0\x6c\xa4\x99\x17\xa5\x35\x99\x69\xd7\xc4\x8f\xa8\x16\xb5\xcb\xb3\xad\x0a\x13\x11\x42\x0b\x6b\x31\x70\x50\x2d\x32\x18\x02\x3f\x03\xf6\xe8\x51\x19\x3a\x83\x12\xd1\xf5\xe6\x91\x9c\xb5\x58\x7b\x1b\xe3\x7a\xab\x24\xa4\x2a\x0a\x79\x0c\x98\x07\x58\xe7\xfb\x96\x28\x86\x9f\x3c\x70\x92\xf9\xd8\xf3\x85\x4b\xb0\x87\xbd\x19\x91\xb3\x5e\x48\xe7\x3e\x71\x69\xab\xff\x7f\xbe\xf8\x67\xff\x69\xbf\x03\xb6\xdd\x2e\x70\xa8\xdf\x07\x39\xa7\xae\x22\x4a\x84\x12\x26\xc2\xf7\xc5\x02\xd4\x8c\x6a\xd4\xb0\x60\x6a\x06\x5f\x2c\x88\x72\x67\xfd\x15\xf3\xd6\x40\xb8\x07\x2e\xe1\xb6\xd2\x13\xa2\x54\x40\x0c\x36\xd4\xee\x4e\x4c\x5f\x8f\x71\x8f\xde\x9c\x4f\x5a\xb6\xc1\x64\xb7\xc1\x41\x06\xe6\xc0\xd3\xae\xc4\x30\x1b\xda\x35\xd0\x3f\xfb\xa5\xe4\x23\x13\x26\x84\xf9\xd9\xc1\x0d\xa9\x9c\x0b\x2e\x69\xd9\xa0\x24\x7c\x22\x3e\x0d\x55\x5a\xb3\xe7\x11\x45\xe0\xab\xaf\x20\x57\xd0\xf3\xa8\x42\xd4\x7f\xfc\x01\xf6\x6b\x11\x50\x35\xc3\x4e\x8d\x43\x71\x45\x0f\xed\x76\xa3\xb1\x79\x1b\xe6\x28\x23\xfa\x4f\x19\x5d\xf1\xb0\xdb\x7d\x84\x92\x7d\x1b\x1e\x25\x0c\x79\x04\xb6\x7e\x35\xb0\x3b\x5b\x95\x94\xa8\x6c\x93\x35\x8d\x25\xcb\xc9\x24\x14\xc1\xcf\x12\x75\x7c\x46\x88\xfc\xd7\x4a\x84\x64\x4a\x7b\x53\xaa\x7e\x52\x34\x68\xd9\x88\x79\xb0\x21\xac\xbd\x9b\x03\x24\x52\x33\x11\xb2\x5b\xea\xed\xa2\x08\x6b\x7f\x14\x8c\xeb\x9a\xd8\x50\xab\x38\xd0\x00\x00\x6c\x02\xad\x43\x53\xad\x6a\xc2\xc5\x5d\x2b\xae\x36\x25\x04\x66\x6b\xdb\xe8\x4a\xd1\x10\x55\xd9\xca\x7e\x16\x53\xad\xe7\x11\xea\x9d\xef\x28\x09\x69\x08\xd8\x75\xd3\x78\x4f\x89\x2b\xca\xd7\xeb\xdd\x83\x21\xdc\x2b\xaa\x4a\x44\xd6\x67\x52\x51\xbe\x8b\x2b\xd8\x5f\x83\xa2\xaa\xbb\xe6\x6b\x4f\x70\xd7\x17\x92\x82\x03\x3c\xf2\xfd\xb3\xba\xaa\xba\x62\xab\xdd\x88\x3d\xba\x0b\x2e\xba\x1a\x65\x1a\x66\x1e\x0a\x25\x5c\xe1\xe3\x5c\xb6\x8d\x85\x64\xc3\x53\xb0\x17\x12\x4d\x25\x1b\x06\xf8\x88\x4f\x67\x07\xcd\xc6\xbb\xac\xd2\xef\x11\x0d\x97\xe0\x24\x95\x9f\x82\xfd\x54\x33\xdf\x29\x8e\x06\x36\x67\x57\xc8\xcc\x96\x66\xaa\x62\x67\xd2\x9a\xfd\x34\xae\x4b\x1d\x15\x46\xd4\x6e\xc4\x2d\xc3\x60\x70\x80\xd3\x45\xca\xad\x77\x74\xfc\x5a\x97\xb7\x62\x46\x3e\x2a\x51\xd5\x42\xaa\x78\x5a\xbd\x0d\xfd\x96\xbd\x90\x76\x1b\x1e\x19\x62\xda\x67\x55\x0d\xf5\x04\x0f\xa8\x94\x24\xbf\xb0\xc5\x45\xd5\xeb\x51\x08\x5a\xd7\x39\xdb\xb3\x3f\x06\xd5\xaa\xaf\xac\xd9\x8c\x8e\xff\x92\xcc\xe7\xfe\xb2\x55\x2b\xbc\xd9\x01\xd0\xda\xd4\x15\x1e\xad\xab\x08\x00\x5a\x95\xb7\x56\x36\xd6\xb7\x07\x9a\xd2\x75\xfb\xac\x16\xc2\x4c\xe1\xea\x3a\xeb\x83\xdd\x94\x31\x0f\x65\x38\x56\x6b\x3b\x28\x2c\x98\xb5\x08\x7f\x76\x50\x0b\xd1\xef\xc3\x5c\x3b\x47\xdb\xcb\x2b\xe3\x4a\x00\x53\x12\x42\x1a\xe0\x22\x57\x8b\x27\x25\x57\xd2\x90\x51\x89\xcb\x55\xe6\xb5\xc7\xe9\x8d\xc2\x32\x33\x9b\xf0\xe9\xb0\xb1\xdc\x67\xff\x43\x5a\x5f\x19\x72\x5a\x45\xfc\x3b\xc6\x62\x7d\xb0\xdf\x97\x75\x7b\x8f\x89\x95\x51\x72\x3b\xe5\x2e\x99\x62\x92\xaa\x37\x2c\xa0\x22\x52\x2d\xa3\x71\x3b\x70\x7c\x74\x74\xb4\xd3\x10\x2b\x51\xe5\x19\xb6\xe4\x0c\x49\xe4\xca\x9d\x17\xb4\xd4\xe6\x41\x34\x67\x95\xc6\x4a\xce\xa8\x4b\x2d\xa4\x12\x7c\xfd\xbe\x96\x2d\xa3\x10\x5d\x12\xe2\xc0\x81\xb8\xa6\x21\x28\xa1\xbf\x08\x35\xa3\xc6\x46\x28\x55\x94\x31\xc5\x28\x3b\x86\xe6\x5d\x0c\xce\x59\x0a\xb2\xc2\x52\xe8\xa4\xba\x46\x09\xad\x69\x56\x36\xf3\xec\x41\xfa\x55\xd7\x47\x17\x00\xba\x89\x4a\xc7\x12\xed\xac\x5c\x51\x5c\x82\x73\xcb\x6e\xbb\x99\xd4\x68\x47\x15\xad\x97\x56\xaa\x58\xf5\xa4\xb5\xdb\xed\x9e\x9a\x51\xde\x2a\xed\x58\x23\x23\xb2\x42\x17\xe4\x8c\xc7\xea\x99\x62\x24\xb1\x55\x31\x97\xd6\x9d\xd2\x62\x54\x8c\x5b\x1f\x76\x5b\x60\xbe\x20\xde\x0f\x26\x78\xb0\xd3\xd2\xa8\xd7\x7f\x46\xcf\xd6\x71\x75\x1f\x8b\xcb\xa0\x99\x0b\xa9\x5a\xb1\xc9\x6b\xc7\xa3\xd2\x78\x00\xd2\xb9\x93\xb7\xd9\x99\x77\xb6\x53\x62\xcb\xe6\x52\x4d\x9f\x93\x06\x4a\x3a\xb8\x7b\x04\x26\x8c\x7b\xcf\xd1\x3d\xcd\x0e\x81\xf6\x57\xab\xc6\xe1\xb0\xf2\x6b\x96\x81\x05\xc7\xb7\x11\xf3\xcb\xfc\xd7\x1c\xa2\x9e\xaf\xdd\xf1\x1d\x2e\x6d\x08\x62\x8e\xdd\x00\xa7\x40\x06\xbb\x2c\xe7\x23\xf6\xca\x80\xf4\xe2\x60\x04\x38\x8e\x09\xd6\xa4\x05\x5f\x7d\x15\x63\xed\xe9\x08\xc5\xa6\x82\x79\xdd\x7c\x4e\x43\x16\x9b\x2a\x69\x51\xdd\x64\x8d\x19\x67\xb0\x54\xcc\xbd\xa6\x46\xb1\x1b\x49\x25\x02\x70\xb2\x31\x97\xe7\xa6\xac\x24\x04\x95\xeb\x68\x26\x04\x93\xe9\x5f\x21\x14\x53\xe8\x56\x89\x33\x93\x1f\x36\x1d\x5f\x30\x44\x95\x4c\xc3\xb8\xe7\xe6\x7b\xad\xc0\xc6\xeb\x87\xc6\x0a\xae\x88\xb8\x92\xe0\x89\x05\x07\x34\x12\x61\x31\xa3\x5c\x7f\xd7\x33\x1e\x16\x44\x82\x4f\xa4\x82\x90\xba\x94\x5d\x53\x6f\x4b\xf6\x93\x0f\xcf\x14\x38\xf0\x82\x28\xda\xe3\x62\x51\x54\x7c\x58\x51\x31\xf7\xca\x04\x0c\xb2\xb5\xca\xa2\x0b\x5f\xea\x60\x40\xa2\x74\x3a\xbb\xfc\xdc\x06\xed\xaf\xb7\x5a\x4a\x22\x9a\xf5\xf6\x6d\x39\xcd\x79\x55\x0e\x27\xda\xd8\x28\xeb\x88\x62\x01\x7d\x49\x27\x39\x5f\xdd\x98\x89\x55\xe6\x84\x1b\x6b\x91\xec\xaa\x63\x04\xa0\xdc\x75\xf2\x0d\x76\x23\x4b\x68\x60\x32\xce\xf8\xf4\xc2\x34\x72\x59\xe1\x2a\xc5\xb5\x23\xce\x75\xdf\x9c\xd8\x74\xc5\xf9\x97\x6b\x17\xff\x8d\xa4\x76\xfd\x88\xcf\xae\xa9\x5d\x35\xf5\x34\x15\x5d\x07\x5a\x09\xbf\xba\x99\x61\x69\x43\x5f\x1b\x64\x8d\xb4\x57\xdc\x9f\xbf\x13\x35\xeb\x05\xe4\xa6\x75\xd4\x31\xcf\x2e\x65\x7e\x0b\x3f\xb6\xdb\x15\x9e\x66\x12\xc5\x75\x0c\x8e\xff\x81\x27\x47\x95\xb3\x44\xa3\x9c\xf8\x42\x84\x1a\x27\xf4\xe1\xc9\x11\x7a\x63\xb6\x36\x68\x5a\x09\xaa\x21\x1c\x1f\xa1\x37\x7a\xa4\xfd\x5c\xed\xaf\xc5\x9f\x6a\x27\x58\xb2\x1e\xb7\xaa\x6c\x90\xc6\xcb\xdf\xfe\xb6\x47\x49\x00\xb7\xb4\x1e\x00\x40\x1a\xd9\xcd\x2f\xae\xba\xb4\x53\x0d\x95\x86\x7e\xf3\x60\xa6\xb8\x06\x2e\x89\x10\xe7\xc1\x74\x69\x0d\x54\x1a\x4b\xce\x83\x99\xe2\xda\xd6\x36\x61\xe7\x62\x8b\xf1\x97\xba\x3e\x9a\x20\x75\xa1\x87\xc2\xab\xeb\x5f\x2e\x8e\x9d\x87\x1c\x0b\x05\x4f\xb7\xcb\x7a\x1b\x10\xc8\x86\xbe\xab\xdb\x48\x42\xe2\xa9\xc1\x91\x8f\x6e\x1a\x4d\xd1\x2e\x47\xb0\x3e\x3b\xd8\xcf\x52\x2d\xb1\x52\xb7\x2c\xd4\x0a\xc5\x17\x2b\x14\x67\xdf\xf0\xa3\x81\xdb\xb2\x33\x73\x58\x7f\x31\xa6\xae\x6d\x97\xd6\xf1\x75\x90\xcf\x6c\xa3\x9a\x00\x7f\x69\x2d\x76\x4d\x71\x8a\xca\xb2\x3a\x89\x4d\xfd\x32\x53\xa9\x76\x95\xd8\xd8\xcb\xb1\x9d\xfb\xd4\x68\x4e\x27\xd6\x9a\x7b\x1a\xbd\x25\x34\xe6\x47\x79\xc2\x7c\x45\xc3\x0d\x42\xac\xd9\xc0\x2a\xc2\x6a\x18\x06\x39\xac\x37\x88\x4b\x1d\xf6\xf6\x4e\x8d\x97\x72\xab\x28\x4a\x9b\xa5\x36\x57\xcd\x78\xe9\x55\x2b\xe7\xa2\xe8\x83\x33\xaf\x2e\xd2\x5f\x34\xf8\x93\x3d\x08\x78\x04\x65\xbe\xc2\x16\x58\x48\x91\xb6\x56\x33\x5f\xeb\x65\x4e\xc4\x1a\x4b\x46\x46\x32\xef\x2c\x12\xb9\x96\x77\x2c\x07\xeb\x76\x13\x33\xd0\x84\xa8\x24\x84\x44\x51\x0f\x88\xd4\x85\x98\x0f\x04\x84\x7b\x70\x45\x97\x20\x26\xba\x4c\xb2\x29\xa7\x1e\x44\xf3\xd8\x5a\xd8\xde\x08\x90\xbf\x26\xb3\x7e\x13\xa9\x14\x1e\xf5\xab\x7c\xa0\xdc\xac\xae\xea\xb9\xc6\xd0\xe3\x66\xce\xe7\x20\x74\xe1\x59\x0d\x10\x12\x5f\x84\xb9\xa2\xcb\x3d\x36\xe8\x34\x9e\x5a\x2e\x26\xeb\x2d\x9b\xf2\xb7\xf3\x66\xd2\x10\x3b\xc4\x86\x20\xdc\xbc\x5d\xd9\xd8\x93\xcd\x46\xe7\x46\xcd\xad\x1f\x3a\x8a\x91\xea\xe5\xb4\xcd\xbc\x66\xc1\xc2\x0e\xd8\x57\x74\x7b\x0d\xbb\xa2\xcb\xf5\x59\x35\xfe\xda\x58\x51\xac\xd6\xb7\x62\x44\x79\x09\xa8\x09\x3c\x16\xa6\xdd\xa7\x8f\xab\x64\xc6\xf5\x3c\x52\x4d\x22\x2b\x87\x09\x0b\x5c\xc1\x27\x2c\x0c\x5a\xf6\x07\x11\x85\x7a\x06\x31\x09\x82\xfb\x4b\xb8\xa2\x73\x05\x0c\xdd\x2b\x26\x71\x8b\x71\x21\x69\xd8\x01\x12\x52\x58\x8a\x08\x64\x14\x3f\x2c\x98\x9c\x81\x12\x7a\xc6\x81\x88\xd4\x53\xbb\x5d\x1f\x28\x68\x16\x2a\x2b\x48\x40\xf9\x26\x52\xe9\x38\x86\x14\xf7\x81\xf3\x2b\xf4\xee\xf5\x20\x3f\x5c\x3b\xfd\xbc\xd8\xdb\xc8\xba\x7b\x25\x09\x27\x09\xb7\x13\x41\x62\xf2\x05\x9d\x60\xdc\x50\x57\xde\xa1\x3e\x33\x79\x28\xd7\xc4\x87\xc3\xd4\xaf\xa9\x08\xee\x67\x94\x54\x02\x5a\x37\xc7\x9a\x0a\xe9\xae\xb8\x74\x05\xb7\x02\x72\x45\x4d\x58\x65\xc3\xa1\x9b\x0e\x2c\x3b\x70\x5b\xe5\x4d\x6a\xfd\x55\xe9\x03\xd8\x37\xf6\x00\x6e\xca\xa7\x8c\x8d\x0a\x60\x59\xf1\xed\xd6\x1e\xc0\x6d\xa7\x89\xd0\xf5\xfb\x30\x0d\xc9\x35\x53\x4b\xbd\xd0\x48\xf0\x42\x31\xd7\xcb\x49\x40\xc2\x2b\x13\x76\x58\xcc\x98\x3b\xa3\x18\xbf\xd6\xc9\x6b\x66\xb9\x29\xc3\xe4\x0a\x3f\x0a\x38\x30\x09\xae\xcf\xdc\x2b\xea\x6d\x55\xca\xee\x03\x6a\x99\xd2\xbe\x8c\x76\x60\x63\x32\xec\xda\x75\x46\x2b\x46\xd3\x0c\xb2\x66\xbd\x47\x0c\x7a\x1e\x65\xa2\xa5\x3a\x8f\xa8\xdd\x31\x48\x3b\x99\x2d\xf2\xd6\xbf\x32\x2e\xbd\x97\x76\x6c\xa2\x1c\x1b\x64\x21\x34\xda\x03\x8e\x57\xdb\x74\xfb\x37\xb3\x3f\x00\x83\x62\x0a\x49\x39\x2d\x2e\xe1\x3f\x0b\xc6\x9b\x05\x5a\xe2\xf6\xb6\xb7\xce\x72\x1b\x6a\xe9\x16\x5b\x3e\x26\x42\x89\x92\xe5\xa5\x69\x06\x4c\xd2\xf2\xb0\x19\xed\x1f\x1b\x13\x9e\x31\x20\x52\x59\x43\x68\x94\xb5\xc4\xfc\x6a\xad\x92\xad\x16\x83\x67\xfd\x80\x22\x77\xaf\x0d\xa1\x9c\x84\xd6\x2e\xf6\x0f\xb7\x77\xb2\xc3\x00\xd6\x0c\x02\x66\x8c\x5e\xad\xb4\x41\x4c\xe2\xd2\xdb\x4e\xa6\x94\x29\x49\xfd\x09\x44\xdc\xa7\x52\x6e\xe9\x27\xd4\x72\xb1\x19\xad\x66\x21\xa5\xe0\xb1\x80\x72\xc9\x04\x27\x7e\xa9\xf7\x59\x34\x93\x6f\x6b\xa4\x34\x27\x67\x1a\x52\xc2\xd3\xb2\xd2\x8b\xdb\x4b\x18\xe4\x3f\x68\xe2\x9b\x88\x20\x93\xef\x98\x8e\x06\x3e\x47\x15\xdc\x6c\x81\x31\x46\x4f\xb6\xb9\x85\xc1\xf1\x92\x71\x5a\x6f\xb0\x94\xa5\x81\x56\xaf\x20\x69\xa6\xb4\x59\x42\x48\x48\x61\x21\x38\x10\x9d\xbc\x6e\x7c\x93\x24\xff\x5a\xee\x5e\x12\x64\x34\xfe\x4e\x57\xad\x22\xf1\x06\x9c\x6c\x80\xf0\x06\xfa\x70\x5a\x21\x8b\xcb\x7c\xd5\x65\x45\xd5\x9a\x2d\xb2\x0a\xf6\xe9\x3c\x6e\xd9\x93\x22\xa0\x1b\x27\x11\xcb\x76\xf0\x15\xab\xf4\x6e\xc0\x71\xe0\x06\x75\x94\x7e\x5d\xe2\xeb\x12\x5f\x35\x86\xde\x2d\xe6\xba\x1d\xe9\xec\xbc\xdb\xbb\x78\x8d\x4c\x3e\xd3\xb9\xea\x5a\x54\x50\xe4\xc3\x88\xea\x5d\x27\x4d\xb3\xb1\x6f\xb3\x43\xa2\x5f\x71\xef\x7b\x3b\x9b\xb0\xdf\x87\x80\x2c\x61\x8c\xe6\x80\x47\x81\xf1\x8e\xb1\x06\x10\x2d\x49\x6d\x81\x00\x8d\x60\xc9\x3c\x5a\x90\x84\x72\x41\xce\x10\x57\x90\xe3\x66\x42\xbc\x53\x3e\x62\x56\x63\xb7\xf7\x19\xea\xbc\x14\x9a\x74\x7f\x1c\x89\x8b\xcb\x76\x61\x9c\x35\xdb\x76\xb4\xae\xeb\x98\x91\xde\x92\x55\x1c\x6a\xf3\x7d\x09\x4e\x33\x01\x6d\x30\xe8\x6f\xc8\x15\xe5\x25\xe3\x6d\x06\xcc\xa4\x8b\x8e\xa9\x59\x74\x3c\x3c\x25\x94\x58\x7e\x65\xaa\x12\x85\x42\x48\x0a\x62\x02\x93\xc8\xf7\x63\xd3\xae\x62\x44\x4d\xcb\xcd\x95\xd2\x1d\x2d\xc0\xb2\x29\xa9\xd9\x78\x71\x73\x79\x71\x74\x09\x23\x38\xba\xc3\xd4\x36\x6b\xf2\x6d\x1b\x91\x2c\x4b\x90\x6c\xb3\x7a\x46\xa4\x16\xc0\xd7\x49\x0c\x3f\xcb\x73\x9c\x4b\xa6\x4b\x86\xd1\x20\x38\x90\x74\xe9\x2a\xa2\x22\x7c\x09\x78\xa2\xa3\x8c\xaf\x5b\xcd\x34\xcb\x27\x2d\x6c\x30\x64\xf8\x9b\xa6\x87\xa3\x54\x57\x57\x4b\x86\x61\x17\x1b\x34\xc4\xf3\x19\x26\x75\x7b\x20\x15\x09\x55\x76\x99\x4e\x10\x9b\x4d\x4b\x2c\xf7\xe8\x84\x44\xbe\x92\x25\x6c\xd8\x30\xcd\x45\xa9\xe3\xa5\xd9\xe3\xd9\xe6\x1a\x18\xb5\x69\x73\xe8\x32\xa4\x5d\x1f\xc0\xc5\x69\x07\xd3\xdc\x4f\x2f\x3b\x9b\xae\x0e\xe0\xe2\x9b\x0e\x3c\xe9\xc0\xe3\xcb\xf5\x45\x29\x67\xaa\x76\xea\x92\x56\x76\x6d\x10\x25\xc8\xf4\x6e\x0a\x38\x29\x75\xa5\x49\x03\x65\x80\x66\x3f\x25\x0b\x79\xdc\x10\x32\xdd\x4e\xc9\x02\x9f\x5c\xee\x99\x7d\x15\xeb\x85\x54\x18\x7d\x4a\xae\xa9\x44\xc5\x9f\xb7\xcd\xd2\x15\x80\x70\x0f\xcc\xa1\xa4\xa9\x8e\x26\x17\xb1\x65\xd6\x91\x05\x59\xc6\x66\x02\x09\x68\x69\x06\x77\xd3\x39\x60\xf6\x19\xd3\xba\x89\x81\xeb\x8a\xf9\xb2\x55\xe0\x4b\x65\x6a\x73\xc5\xf4\xab\x4e\xc3\xf2\xa8\x4f\x15\x85\xfc\x18\x9f\x35\xaa\x6a\x46\xf5\xac\x21\xda\x78\x1c\xf7\x51\x72\x09\x74\x13\x1b\x33\xe2\x9e\xd8\x23\x42\x9a\x3a\x38\x08\x87\x0e\x0e\x06\xac\x3e\x1b\x5f\x1a\x9a\x9e\x6e\x7a\x70\xb7\x25\xd1\x57\xf4\x6e\xdc\x0c\xe9\x67\xc8\xcd\x07\x63\x8e\xd1\xcc\x2e\xda\x8a\x8d\x4e\x64\x94\x70\x28\xae\xfc\x9f\xca\xa1\x54\x7c\x74\x74\xb9\x41\x5c\x7b\x2b\xac\xfd\xac\x2a\x5e\x6d\x70\xd6\x44\xab\xcd\xc8\xb4\x6c\x53\xd1\xde\x37\x43\x37\x26\x5d\x4c\x26\x34\x7c\x11\x92\xc5\x2e\xea\x93\xe6\x34\x40\xd7\x0b\xc9\xc2\x6e\x14\xf4\x27\xae\x4b\xe7\x6a\x9f\x16\x0c\xc4\x1e\x4d\x78\xd4\xc5\x13\xac\xfb\xb4\x11\x83\xec\xd1\x08\x93\x88\xff\x1c\x7b\xbf\xdb\xa6\x29\xb3\x7e\xbd\x04\x1c\xcd\xd6\xad\x98\xd7\xe6\xeb\xa1\x03\xb9\xa0\x59\x13\xe2\xc6\x54\xaa\xf3\x89\xde\x7f\x3f\xd2\x16\xd3\xd7\x1d\xf8\xa6\xea\x34\xa2\x4e\x71\x46\x43\xcb\x40\xe9\x73\x8c\x15\x87\x00\xb7\xb3\xb7\x9b\x6b\x46\x0d\x8a\xca\x31\xd3\x50\x8e\x86\x98\xea\xf5\x27\x52\x0b\xd9\xac\xfc\x62\xc2\xeb\x9f\xb5\xe3\x85\x19\x5c\x54\xa5\xfb\x43\x5b\xda\x75\x41\x98\xfa\x5e\x84\xe7\xf3\xb9\xe0\x3a\x3f\x73\xd3\x45\x03\x5a\xca\xee\x02\x6e\xf3\x50\x76\xea\x65\xc1\x70\xf4\x62\x54\xf1\x96\x50\x15\xcb\x5c\x22\x29\xee\xf4\x33\x34\x41\xec\x41\xb5\xb6\xcd\x6e\xc2\x8b\xf1\x78\xd9\xff\x3d\xa2\x11\xd5\xd9\x01\x71\x43\x31\xe9\x35\x03\x79\xa7\x01\xcd\xd8\xa3\xda\xfe\xa4\x24\x74\x67\xe8\xe0\xc1\x98\xea\xe4\x7d\x8e\x3e\x34\xf5\x20\xa0\x84\x2f\x66\xcc\xa7\x3b\x51\x65\x1c\x5c\x43\x74\x66\x5e\xe6\x3a\x03\x8e\x53\xe8\x5d\x03\x42\x01\xa0\x38\xc2\x85\xb8\xed\xd9\x4e\x1c\xeb\xda\x1a\x15\x02\x7b\x2f\xf6\x96\x8b\xee\x2e\x28\x7d\x0c\x29\x6d\xe6\xae\x87\x5e\x6a\x00\xc7\x21\x25\x57\x67\x35\xa2\xab\x67\x3a\xf5\xea\x44\x77\xaf\x9e\xa5\xd9\xf2\xf1\xb8\x33\xaf\xba\xee\xfd\x62\xf9\xb1\x44\xb5\x9b\xe1\x6f\x9a\x8a\x0f\x77\x3e\xd1\xf1\x10\xe2\xb3\xa7\xf5\xdf\x6c\xb7\xa2\xa9\xd8\x97\xe9\xeb\xfb\x89\x58\xec\x8b\x3f\x94\x6c\xe5\xcf\x54\xdb\xbf\x08\x7d\x1b\x0c\xf5\x65\x9c\x23\x2e\x84\x4e\xfc\xc5\x18\x15\xd1\x83\xdc\x01\x15\x2e\x81\x4c\x09\xe3\xe0\x13\x45\xc3\xc3\xbb\x1a\x7c\x98\xec\x58\xb6\xde\xec\x4c\xb1\xc9\x28\x7b\x3b\xb3\x41\x56\xeb\xb6\x3f\xdc\x6a\x7e\x17\x3d\xfa\xe0\xcb\xb9\x59\x62\x5e\x9b\x85\xa7\x41\x74\x23\x95\x86\x9c\x74\x9c\x1d\xdc\x4d\x78\xcc\x68\x98\x48\x43\xc3\xc5\xd7\x78\x5d\xd8\xe1\x46\xfd\xe3\x74\xd1\xf4\xe8\xd3\x61\x73\x4f\x46\x87\x1b\x81\x00\xa7\x0b\x2d\xcb\x0f\x93\x81\x63\x4e\x4b\xfc\x1e\x51\xa9\x76\x9c\x61\x4e\x62\x32\x98\x3f\x90\xb5\xae\xb5\xdd\xad\x0b\xff\xf8\xa3\x10\xe7\x1e\x8b\xac\x25\x90\x62\xc8\x64\x18\x17\xf1\xe4\x13\x90\x6b\x3a\xa8\x29\x06\x27\x1e\xcd\xdc\x79\xae\x4e\x2e\x7c\x96\x9a\xc2\x7b\xcd\x93\x3d\xcf\x78\xdd\x67\x71\xd9\xad\xab\x77\x9e\x06\x83\xaa\x0c\x59\xa3\x0b\xf7\xe0\x61\xba\xbe\x71\xba\x40\xa7\x23\xcf\xc9\x82\x8b\xd1\x30\x28\xa7\x9b\xf9\x5f\x12\x15\x4b\x9f\x2e\xdb\xc9\x8d\x4f\xf1\xcd\x4b\xc3\xbe\xb9\x35\xef\x60\xa8\x17\x29\x3e\xed\x6e\x2e\x49\x72\xac\xe4\x92\xa4\xe4\x96\x33\x8f\x5d\x83\xeb\x13\x29\x1d\x8b\x93\xeb\x31\x09\xc1\xfc\xe9\x32\x7e\x4d\x43\x49\x93\xd7\x09\xbb\xa1\x1e\xde\x61\x15\x03\x16\x81\xb1\x0d\xc2\x38\x0d\x33\xdf\xcb\x1b\xe8\x9a\x9b\x28\x0a\xf5\x00\x00\x86\xa4\x50\x73\x1c\x12\xee\x25\xd7\xc9\x7d\x61\x8d\xde\x51\xdf\x15\x01\x05\x25\x40\xdf\xb4\x67\xe1\xd5\x50\x16\xde\xb5\x77\x38\xec\x93\x42\xc3\x7d\x8f\x5d\x8f\x0e\x4a\x5e\xe3\xc7\x83\xca\x2e\xe8\x6d\x57\xda\x95\x33\xb1\x40\x53\xd5\x82\x50\xf8\xd4\xb1\xf0\x78\x51\x45\xef\x43\xb1\xa8\xe9\xb7\x2b\xfc\xae\x0c\xba\x62\x32\x91\x54\x75\x1f\x43\xfc\xfe\x18\xf0\x4a\xac\xae\x4b\x31\x43\x5c\xdf\x81\x18\xd7\x5f\xd9\x49\xa0\xdf\x1e\x40\x61\x5f\xb6\x03\xb6\x0e\xfc\xa7\x5f\x4c\xfa\xc3\xba\x8c\x9d\x19\x12\x74\x2d\xdd\x46\x48\xe7\x94\x28\xc7\xba\x05\xc6\x41\xdf\x1a\xd4\x32\x88\x3c\x3a\x57\x33\x54\xad\xc7\xed\x12\x64\x00\x00\x43\x19\x10\xdf\x4f\x50\x6a\xe2\x83\x48\x51\x4f\xe3\x45\x6e\x39\x96\x41\x65\x68\xb2\x46\x2f\x89\xbe\x30\x6d\x75\x8b\x77\x40\xad\xd7\xc3\xbe\x46\x50\x81\x1c\xa9\xcd\xd0\xb7\x2c\xd2\x67\xc2\xf9\xed\x3c\xa3\x8a\x77\xcf\x61\xde\x1e\xfc\x0f\x9c\x82\xe3\xc0\xc9\xba\xa2\x1f\xa6\x2f\x73\xc2\xb3\xed\xdd\x14\xdb\xd3\x3b\x0d\xed\x1a\x14\x00\x00\xc3\x71\xa4\x94\xe0\x09\x4f\xc6\xca\x64\x21\xc4\x44\x32\xf7\xca\xb1\x92\xcc\xc5\x74\xe3\x56\x7f\x4c\x34\x84\x63\x25\x4f\xc8\xfa\x4c\x32\xd6\x1f\x7f\x24\xdb\xce\x29\x20\x96\x1d\x66\xf7\xf8\xf5\x97\xb6\x55\x4b\x21\x00\x64\x38\x76\x91\xcb\x75\xd9\x60\x7e\x0a\xf6\x58\xf1\xee\x82\x84\xf8\xcd\x06\xbc\x25\xe6\xc8\x1e\x98\xd2\xd8\x98\xc6\x23\x9f\xc7\x49\x19\xe3\x13\x81\x05\x27\x49\x81\x8c\x5c\x97\x4a\x69\xaf\x2f\xf2\x9b\xbe\x97\x1d\xc8\x0e\x53\x18\x1f\x68\xba\x49\x47\xa9\x03\x76\xf6\xfa\xbf\x8d\xc8\x17\xcf\xdf\xe1\x0a\xbf\xdd\xfb\xf5\xa5\x35\xda\xc9\x80\xa1\x54\xa1\xe0\x53\x2d\xa7\x3a\xc8\xe2\x58\x79\x2a\x1b\xe0\xc8\x49\x8d\xc1\xd2\xc5\xf3\xa2\x8e\x75\x6c\x8d\xde\x0f\xfb\xf8\xe9\x3e\x58\x4e\xac\xd1\xf9\x7d\xb0\xc4\xa3\x64\x44\x71\xd4\x14\xd3\xb0\x6f\x58\xb3\x43\xcc\xfb\x46\xce\x77\xcc\xa7\x8a\x99\x9d\xd7\xc4\x35\xc5\xe5\x3a\xbb\x4a\xe3\x42\xe6\x9a\xc5\xfb\x6a\xdf\x6d\xf2\x9e\x47\x61\x48\xb9\x82\x37\x51\xc8\x07\x07\x0d\x04\xca\x48\xad\x49\x3b\xac\x54\xa0\xe5\xe2\x93\x53\xa9\x38\xb3\x76\x08\x54\x95\x00\xe5\xf0\xc4\x13\xb2\x46\xaa\xaa\xc7\xbe\xd0\x40\xd2\x39\x33\x25\xf7\xea\x1c\xe5\x5e\x9e\x2c\x0f\x55\x6c\xd8\x44\x33\xe7\xdb\xc6\xfc\xb0\x5a\xb8\xfb\xcf\xcf\xbb\xcf\xcb\x5d\xdf\xdf\x31\x2e\x0f\x6b\x7a\xac\xd7\xd5\xc2\x22\x6a\x8e\x94\x5a\xa3\xcd\xa5\x26\xae\x08\xe6\xe8\x4c\x7a\x40\x00\x77\x43\xea\xd7\xd3\xbd\xe5\x07\xb7\x34\xca\xc7\x0a\x77\x52\x0e\xf7\x47\xa8\xcc\xc5\x34\x9f\xc3\xf8\x9f\xdf\x6b\xfc\xdf\xdf\x77\xfc\x43\xa2\xcf\xb2\x80\x98\x00\x32\xe5\xf0\x81\x46\xcc\x6c\x25\x52\xef\xbf\x1c\x86\x84\x15\x0f\xc5\x5a\x32\x26\xdc\x13\xff\xe5\x2d\x24\xd7\x12\x24\xc7\x25\x1f\x8c\xc1\xd3\x90\x52\xaf\x5b\xaf\x74\x38\x8c\x31\x8a\x1a\x52\x7d\x49\xc8\x61\xcd\x42\xa6\xcb\x3f\x5b\x63\x02\xed\x7f\xf8\x62\x00\xab\x95\x11\x07\x1e\x05\x58\x24\xd7\xeb\x9a\xa5\x37\xb3\x16\xe8\xa3\x62\xda\xa5\xfa\x2a\x60\x9e\x27\xd4\x59\x8a\x2a\xf9\xb4\x5e\x83\x7e\x64\x7c\x5a\xc9\xa6\x02\xea\xd4\xd9\xb0\x36\x6e\xe2\x98\xfa\xa0\xff\x4d\xec\x49\x6b\xf4\x3a\xad\x57\x86\xf8\x1e\x6c\x46\x52\xd8\x24\xe9\xa3\x3e\xbd\xff\xf0\xac\x4f\x3b\x9d\xfa\x8c\xd9\x4e\x6a\x37\x66\x00\x19\x0a\xb2\x37\x83\x1c\xaf\xad\xd1\x7b\x58\xad\x92\x9b\x4c\x5a\xc7\xed\xf5\xba\x8c\x09\x00\x00\x5f\xf1\xb1\x9c\x9f\xed\xd9\x7e\xe2\x35\x55\x92\x80\x4e\xec\x79\x96\x84\x93\x0a\x12\x3e\x27\x71\xdf\x56\x82\x3a\x33\x62\x4f\xab\x78\xf4\x41\x44\x40\x42\x93\x55\x8d\xec\x48\x8c\xee\x72\x6b\x59\x7f\x1b\xdd\xc5\x6c\x6e\xd4\x50\xce\x9c\xae\x6f\xab\xe9\xfc\x33\xaa\x00\x43\xc9\x95\x9c\xa9\xea\xf0\x6a\x95\x81\xbe\x38\xbe\xd4\x37\xf8\xfe\x80\xf1\x4f\x5b\x8b\x47\x8d\x4f\x77\x2d\x1b\xb7\x95\xf6\x39\xdf\xdc\x49\xd3\xe6\x2a\x19\xb1\x1d\x35\xc9\xc4\x19\xf4\xb3\x0c\xb2\x31\x14\x3c\xe6\xd5\x3a\x6e\x67\x02\x4d\xf1\x91\x37\x2c\x1c\xe1\x03\x10\x09\xef\xab\xdd\xd4\xbb\xb5\x78\x52\xd6\xe2\x49\xa6\xc5\xf3\xf2\x16\xff\xcc\xb9\x78\x62\xdd\xa9\xb7\x63\xad\x6d\x33\x1d\xc6\x44\xd3\x56\x31\x36\x75\x18\x6b\x25\xc2\xdf\x72\x4f\x58\x23\xfc\xb7\x51\x97\x2b\x88\x7f\x28\x62\x43\x5a\x4b\xec\x2b\x8a\xc4\xbe\xa2\x0d\x89\xfd\xb7\x18\x9f\x34\xf1\xaf\x55\x1b\x40\xcc\x27\xa7\x59\x23\xfd\x07\x10\xee\xf3\x18\x37\xb4\xd2\x2b\x7b\x80\x63\x86\x15\x1e\x7c\xd4\xf2\x96\x86\x49\x67\x7b\xf8\x65\x4f\xef\xc5\xae\x56\xd9\x46\x7a\x68\x32\xaf\xd7\xa5\x9a\x3f\x97\x55\x67\x8d\x40\x4c\x00\x9f\xf1\x6f\x01\x89\xa9\x92\xae\xfb\xd5\xe1\xa8\x92\x95\xb1\x88\x4b\xba\x22\xa4\x17\xc7\x97\x75\x7a\xbb\x7b\x70\xa7\xd5\x21\xd7\xc2\xc9\xe5\x7a\x0d\xe7\x4d\x42\x4c\xdb\x2c\x41\xf9\x95\xd6\xa8\xb5\x5a\x6d\x17\xaf\xd7\x80\x7f\x79\xbb\x7a\x95\xdd\x84\xe6\xb6\x51\xc7\x8e\x58\xa9\xc7\xb1\x5a\x95\x54\xd5\x76\x20\xc6\xc7\xdf\xeb\x7b\xd0\xce\x6d\x34\xb4\x19\x8f\xcf\x54\xe8\x9a\x87\xe5\xbd\xbc\xa7\xbc\x1a\xe2\xd3\xc9\x8d\x87\xb3\x5b\x59\xfa\xb0\xa4\x84\xde\xf6\x5d\xe4\x3a\xfb\xbb\x32\xbb\x4c\x3b\xf3\xb3\x33\x31\x46\x0d\x18\xef\x2f\x9a\x9f\xaf\x49\xd6\x51\x7d\xfa\xdf\xb1\x62\xda\x62\x21\xc7\x0f\xe6\x7a\x4a\xdc\xe4\x03\x22\xa1\xc5\x91\xb7\xdf\xc5\x72\x8f\x79\x11\xfa\x9e\xf3\xf3\x39\xe5\x31\x77\xed\x36\x92\x07\x1c\x18\x07\x83\x46\x6a\x3c\x33\xe6\xd1\xe2\x8c\x1e\xf6\x0d\x79\x0f\x60\x00\xc4\x09\xb1\x68\xb5\xc6\x59\xa9\x9f\x44\x2b\x99\x51\x3e\xcc\x0e\x73\x2e\x73\xb9\xd5\xfe\xf4\x2e\x51\x61\xd1\x48\x25\x3e\x55\x23\x5a\xf2\xb3\x73\xde\xde\x4c\x96\x6d\xb0\xcd\x44\xa9\x9a\xa2\x7a\x2d\x93\x40\xf4\x4c\xbe\xff\x50\x6d\x52\xd6\x91\x5b\xcf\xf4\xdb\x03\x9a\x82\x99\x74\x75\xc4\xff\xc2\xbc\xfe\xeb\x2d\x8b\x4f\x3b\x6d\xb3\x07\xf4\x0c\x37\xf4\xf1\x40\xc7\xca\x9e\x15\xac\xf6\xe9\xcc\x2c\x37\x3f\xf2\xe2\x58\xc9\x11\x41\x6b\xf4\x3a\x7e\x1a\xf6\x4d\x8d\x46\xe0\xc9\x76\xb8\x35\x7a\x1b\x3f\xed\x05\xae\x37\xcc\xad\xd1\x3f\xf0\x0f\xb4\x1e\xdf\x3c\xbe\x79\xdc\xde\x0b\x41\x7c\x94\xd1\x1a\xfd\x60\x1e\xa0\xf5\x5c\x70\x8e\xfc\xfc\x5e\x44\x61\x0d\xae\x1a\x7d\x64\xa2\x00\xc9\x08\xcf\xa8\x7b\x85\xbf\x7a\x66\x06\xd3\x02\xfd\xcb\x81\x8e\xf5\xdc\x44\xfc\xd1\x27\x35\x21\x7f\xc0\xfb\xe1\x2b\xdd\x45\x3d\x92\xf1\xcf\x6d\x25\x28\x4b\x47\x35\xd9\x5b\x80\xbf\x33\xf9\xff\xfe\x6f\x48\x4b\x08\xd7\xf4\xed\x5c\xb6\xb7\x0f\xf2\x35\xa1\x8d\x47\xc1\x38\xb3\x08\x57\x48\x63\xc0\xcc\xe6\x58\x40\x6e\x1c\xeb\xf8\x2f\xa5\x1d\xd1\x9b\xf4\x29\xbb\xde\xe9\xb7\x72\x02\x6e\xfe\x4c\xb2\x4c\xae\x42\x4a\xd7\x8f\xe6\xb5\x9c\x30\x7d\x5a\x9c\xa0\x2a\xf8\x73\x19\x17\x1f\x78\xcc\x30\x8f\x43\x5c\xd4\xcc\x93\xff\x8f\xd3\x75\xf8\xde\x4c\x9f\xe9\x9f\xef\xb2\x46\x6f\x16\x22\xf9\xe1\x8b\xbd\xf4\xc9\x58\x28\x6b\xf4\x1b\x0d\x65\x24\xf5\xa6\x5e\xa4\x68\x78\x27\x25\x72\xd7\x9e\x6e\x32\x1f\xb3\xb9\x3b\x59\x3e\xe8\xbc\x8b\xb1\x50\x76\x33\x86\x60\xa2\x96\x08\xac\xd1\x2b\xfd\x77\x4f\xe5\x4a\xa9\xa7\x75\x2b\xfe\xdd\x0b\x94\x05\xf1\xad\xb1\xd6\xe8\xa7\xe4\x71\x2f\x04\x29\xf8\xaf\xbb\x80\x3f\xc1\x20\xb8\xa9\x77\x9c\x9a\xc4\xba\xa8\x67\x96\x06\x7d\xc9\x04\xbe\x03\xe3\xe6\xa1\xd2\xc0\xfd\x53\x67\x62\xf3\x50\xc0\x3c\x64\x01\x09\x97\xe5\xa1\x80\x38\x77\x19\x17\x8c\x5f\xe8\x42\xfb\xce\x9f\x81\x49\xf5\x40\x71\x8e\x6c\xd2\x7e\xab\x9d\x71\x57\xe2\x94\x71\x8c\xd4\xe4\x2c\x2c\x7d\x1d\x5f\x7a\x91\x82\x35\xfa\x9e\x71\x0f\x12\x0c\xf7\xb2\x65\xb7\x68\xcb\x26\xc5\xb7\xb2\x01\x4e\x43\x9b\x35\x7a\x67\xce\x94\x99\xd3\x0c\x1c\x44\x4c\x45\xaf\xd7\x83\xe7\x1a\xf6\xdf\xcd\xf4\x8d\x2d\x16\x33\x02\xf5\x09\x3a\xd9\xc5\x16\xd1\xee\x5c\x6a\xc9\x8d\xf9\x41\x09\xc7\x3a\x3d\xc9\xce\xf2\xcd\x35\xa6\x16\xe8\x5f\xf9\x9b\x09\xdf\xc3\x04\x5c\x7d\x2f\x26\xc6\xd1\xab\x48\xd8\xd7\x3d\x31\xb7\xaf\x6e\x47\x43\x33\x14\x8c\x5e\xb3\x29\x87\xb7\xf3\x1a\x41\x6a\xb6\x65\x51\xcb\xbc\x5f\xe3\xdd\x13\x73\x99\x6e\x7c\x5d\x95\x4c\xe2\x31\xa3\xd5\x2a\x73\x73\xed\xae\x4d\x8a\x0a\x2e\xe8\x9f\x9b\x2e\x67\xc1\x79\xa4\x77\x98\x74\x4f\xcf\x23\xb5\x5f\x57\x1f\xc4\x89\x4f\xaf\xac\x8e\x7f\x63\xe4\x81\xd4\x8f\xc2\x01\x4d\x00\xcd\x8b\xfe\x17\xa5\xd1\xa3\x5c\x52\xaf\x04\xca\x40\x6e\x7e\x3e\xbd\xfc\x7b\x38\x1a\xaa\xd9\x08\x2f\xa5\x8e\xf5\xaf\x9a\xe9\x92\x5f\x13\x9b\x26\x7e\x37\xdb\xd7\xe9\xeb\xbb\x74\x9f\x39\x2e\x30\x0f\x7d\x15\x56\xa5\x22\xd5\x50\x32\x54\x98\xa6\x5e\x4b\x65\x36\x4d\x17\x99\x0c\xcc\x5c\xe7\xfd\x43\xcd\xd6\xd9\x06\xdc\x1b\xad\x56\x58\xdb\xf8\x0a\xeb\xf5\x4d\xfc\x6a\x6c\x74\x14\x44\xe5\x35\xc6\x91\xdd\x6f\xd3\x37\x8f\xeb\x83\x24\xc9\xb5\xb2\x71\x00\xe4\x3b\xa1\x74\x08\xc4\x6c\x90\xb5\xd7\x6b\xb8\x96\x90\x43\x70\x52\x89\xe0\xa4\x14\xc1\x7e\x34\xa6\xc9\x05\x7b\x81\x6d\x12\x09\x9a\x81\xed\x37\x45\x35\xf6\x56\x7c\x09\x7b\xdb\x32\x32\x94\x4e\xd2\xfa\x16\x6b\x25\xab\x5c\x7a\x86\x7d\x3d\x47\x3e\xc5\x2c\xdf\xdc\x71\xfb\x6f\x36\xcf\xbf\x28\xcc\xef\xf4\xf5\x55\x9c\xdc\x91\x4c\xef\xf4\xe9\x65\xfa\xf4\xe2\x4f\x9c\xe2\x94\xe3\xd9\x49\x9c\xe3\x1b\x56\xe7\xb3\xfe\xe3\xac\x8d\xcd\x0f\xb7\x68\x90\xf8\x9e\xf4\xe4\x07\x5d\xcc\x3a\xd3\x48\x3d\x18\xf0\x90\xf0\xab\xe6\x73\x66\xd3\xe4\xbe\x30\xa1\x66\xf7\xbe\x50\xb8\xe7\xb0\x2f\x8c\x2f\xa4\xa4\x7b\x43\xc5\xdb\x2c\xff\xca\x39\x99\x79\x1c\xf6\x0d\x36\x3c\xd7\xa4\x02\x7f\x74\xf0\xff\x07\x00\x77\xf5\xe1\xfc\x4c\x84\x00\x00")
 
 func indexHtmlBytes() ([]byte, error) {
 	return bindataRead(
@@ -83,7 +83,7 @@ func indexHtml() (*asset, error) {
 		return nil, err
 	}
 
-	info := bindataFileInfo{name: "index.html", size: 5042, mode: os.FileMode(436), modTime: time.Unix(1489808525, 0)}
+	info := bindataFileInfo{name: "index.html", size: 33868, mode: os.FileMode(436), modTime: time.Unix(1792297822, 0)}
 	a := &asset{bytes: bytes, info: info}
 	return a, nil
 }
@@ -0,0 +1,221 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+)
+
+// Game modes
+const (
+	ModeHuman = "human"
+	ModeBot   = "bot"
+)
+
+// Evaluator is implemented by rules which score positions for the bot
+// themselves
+type Evaluator interface {
+	// Evaluate scores the game for the player, the higher the better
+	Evaluate(game *Game, player int) int
+}
+
+// SearchDepth is implemented by rules which limit how many moves ahead the bot
+// searches themselves
+type SearchDepth interface {
+	// SearchDepth is how many moves ahead to search, zero searching to the end
+	SearchDepth(game *Game) int
+}
+
+// MoveOrderer is implemented by rules which pick the moves the bot searches
+// and their order themselves
+type MoveOrderer interface {
+	// OrderMoves returns the moves worth searching, the most promising first
+	OrderMoves(game *Game, moves []Cell) []Cell
+}
+
+// botState adapts a Game so the ai package can search it.  The game is played
+// on directly so it should be a clone of the real one.
+type botState struct {
+	game *Game
+}
+
+func (s *botState) Player() int {
+	return s.game.Player
+}
+
+// Moves returns the legal moves under the rules of the game, in the order of
+// the rules when they are a MoveOrderer and otherwise nearest to the centre
+// first.
+func (s *botState) Moves() []ai.Move {
+	rules := s.game.Rules()
+	cells := rules.Moves(s.game)
+
+	if orderer, ok := rules.(MoveOrderer); ok {
+		cells = orderer.OrderMoves(s.game, cells)
+	} else {
+		sortByCentre(s.game, cells)
+	}
+
+	var moves []ai.Move
+
+	for _, cell := range cells {
+		moves = append(moves, ai.Move(cell))
+	}
+
+	return moves
+}
+
+func (s *botState) Play(move ai.Move) error {
+	return s.game.play(move.X, move.Y, move.Z)
+}
+
+func (s *botState) Undo() error {
+	return s.game.takeBack()
+}
+
+func (s *botState) Outcome() (bool, int) {
+	switch s.game.Status {
+	case StatusEnd:
+		return true, s.game.Winner
+	case StatusDraw:
+		return true, 0
+	}
+
+	return false, 0
+}
+
+// Evaluate scores the game by the rules when they are an Evaluator, and
+// otherwise every window of WinLength cells on the Board, a window only counts
+// for the player holding all of its marks and is worth more the fuller it is.
+// Lines are a liability in misère games so the score is turned around.
+func (s *botState) Evaluate(player int) int {
+	var score int
+
+	if evaluator, ok := s.game.Rules().(Evaluator); ok {
+		score = evaluator.Evaluate(s.game, player)
+	} else {
+		score = scoreWindows(s.game.Board, s.game.WinLength, player)
+	}
+
+	if s.game.Misere {
+		score = -score
+	}
+
+	switch {
+	case score >= ai.MaxScore:
+		return ai.MaxScore - 1
+	case score <= -ai.MaxScore:
+		return -ai.MaxScore + 1
+	}
+
+	return score
+}
+
+// scoreWindows sums the worth of every window of winLength cells on the board
+// to the player
+func scoreWindows(board [][]int, winLength, player int) int {
+	score := 0
+
+	for _, dir := range [][2]int{{1, 0}, {0, 1}, {1, 1}, {1, -1}} {
+		for x := range board {
+			for y := range board[x] {
+				mine, theirs, ok := countWindow(board, x, y, winLength, player, dir[0], dir[1])
+				if !ok {
+					continue
+				}
+
+				score += lineWeight(mine, theirs)
+			}
+		}
+	}
+
+	return score
+}
+
+// countWindow counts the marks of the player and their opponent in the window
+// of winLength cells starting at x, y.  ok is false when the window does not
+// fit on the board.
+func countWindow(board [][]int, x, y, winLength, player, dx, dy int) (mine, theirs int, ok bool) {
+	for i := 0; i < winLength; i++ {
+		cx, cy := x+i*dx, y+i*dy
+
+		if !isOnBoard(board, cx, cy) {
+			return 0, 0, false
+		}
+
+		switch val := board[cx][cy]; {
+		case val == player:
+			mine++
+		case val != 0:
+			theirs++
+		}
+	}
+
+	return mine, theirs, true
+}
+
+// lineWeight is the worth of a window or line to the player holding mine of
+// its marks, it only counts for whoever holds all of them
+func lineWeight(mine, theirs int) int {
+	switch {
+	case theirs == 0 && mine > 0:
+		return windowWeight(mine)
+	case mine == 0 && theirs > 0:
+		return -windowWeight(theirs)
+	}
+
+	return 0
+}
+
+func windowWeight(marks int) int {
+	if marks > 8 {
+		marks = 8
+	}
+
+	return 1 << uint(2*marks)
+}
+
+func hasNeighbour(board [][]int, x, y, distance int) bool {
+	for dx := -distance; dx <= distance; dx++ {
+		for dy := -distance; dy <= distance; dy++ {
+			if isOnBoard(board, x+dx, y+dy) && board[x+dx][y+dy] != 0 {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+// sortByCentre orders the cells nearest to the centre of the board first
+func sortByCentre(game *Game, cells []Cell) {
+	width, height := game.Width(), game.Height()
+
+	sort.SliceStable(cells, func(i, j int) bool {
+		return centreDistance(cells[i], width, height) < centreDistance(cells[j], width, height)
+	})
+}
+
+func centreDistance(cell Cell, width, height int) int {
+	dx, dy := 2*cell.X-(width-1), 2*cell.Y-(height-1)
+	return dx*dx + dy*dy
+}
+
+// botDepth limits the search on boards too large to be searched to the end, by
+// the rules when they are a SearchDepth and otherwise by the area of the board
+func botDepth(game *Game) int {
+	if depth, ok := game.Rules().(SearchDepth); ok {
+		return depth.SearchDepth(game)
+	}
+
+	switch area := game.Width() * game.Height(); {
+	case area <= 9:
+		return 0
+	case area <= 16:
+		return 4
+	case area <= 49:
+		return 3
+	}
+
+	return 2
+}
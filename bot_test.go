@@ -0,0 +1,253 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+)
+
+func TestBotState_Moves(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	_ = game.MakeMove(0, 0)
+
+	moves := (&botState{game: game}).Moves()
+
+	if 8 != len(moves) {
+		t.Fatalf("unexpected moves: %#v", moves)
+	}
+
+	if expected := (ai.Move{X: 1, Y: 1}); expected != moves[0] {
+		t.Errorf("unexpected first move: %#v", moves[0])
+	}
+}
+
+func TestBotState_Moves_Nearby(t *testing.T) {
+	game, _ := NewGame(15, 15, 5)
+
+	if moves := (&botState{game: game}).Moves(); 225 != len(moves) {
+		t.Errorf("unexpected moves: %d", len(moves))
+	}
+
+	_ = game.MakeMove(0, 0)
+
+	expectedMoves := []ai.Move{
+		{X: 2, Y: 2}, {X: 1, Y: 2}, {X: 2, Y: 1}, {X: 1, Y: 1},
+		{X: 0, Y: 2}, {X: 2, Y: 0}, {X: 0, Y: 1}, {X: 1, Y: 0},
+	}
+
+	if moves := (&botState{game: game}).Moves(); !reflect.DeepEqual(expectedMoves, moves) {
+		t.Errorf("unexpected moves: %#v", moves)
+	}
+}
+
+func TestBotState_Outcome(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	state := &botState{game: game}
+
+	if over, winner := state.Outcome(); over || 0 != winner {
+		t.Errorf("unexpected outcome: %t %d", over, winner)
+	}
+
+	game.Status = StatusDraw
+
+	if over, winner := state.Outcome(); !over || 0 != winner {
+		t.Errorf("unexpected outcome: %t %d", over, winner)
+	}
+
+	game.Status = StatusEnd
+	game.Winner = 2
+
+	if over, winner := state.Outcome(); !over || 2 != winner {
+		t.Errorf("unexpected outcome: %t %d", over, winner)
+	}
+}
+
+func TestBotState_Evaluate(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	state := &botState{game: game}
+
+	if score := state.Evaluate(1); 0 != score {
+		t.Error("unexpected score:", score)
+	}
+
+	_ = game.MakeMove(1, 1)
+
+	if score := state.Evaluate(1); score <= 0 {
+		t.Error("unexpected score:", score)
+	}
+
+	if score := state.Evaluate(2); score >= 0 {
+		t.Error("unexpected score:", score)
+	}
+}
+
+// cornerRules play on a standard board, the bot only ever looks at the corners
+type cornerRules struct {
+	StandardRules
+}
+
+func (cornerRules) Name() string {
+	return "corners"
+}
+
+func (cornerRules) OrderMoves(game *Game, moves []Cell) []Cell {
+	var corners []Cell
+
+	for _, cell := range moves {
+		if cell.X%2 == 0 && cell.Y%2 == 0 {
+			corners = append(corners, cell)
+		}
+	}
+
+	return corners
+}
+
+func (cornerRules) Evaluate(game *Game, player int) int {
+	return 7
+}
+
+func (cornerRules) SearchDepth(game *Game) int {
+	return 1
+}
+
+func TestBotState_Rules(t *testing.T) {
+	defer registerTestRules(t, cornerRules{})()
+
+	game, err := NewVariantGame("corners", 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	state := &botState{game: game}
+
+	expectedMoves := []ai.Move{{X: 0, Y: 0}, {X: 0, Y: 2}, {X: 2, Y: 0}, {X: 2, Y: 2}}
+	if moves := state.Moves(); !reflect.DeepEqual(expectedMoves, moves) {
+		t.Errorf("unexpected moves: %#v", moves)
+	}
+
+	if score := state.Evaluate(1); 7 != score {
+		t.Error("unexpected score:", score)
+	}
+
+	if depth := botDepth(game); 1 != depth {
+		t.Error("unexpected depth:", depth)
+	}
+}
+
+func TestGameService_Bot_Replies(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	service := NewGameService(game)
+
+	if _, err := service.SetBot(ai.NewPlayer(ai.Perfect), 2); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	snapshot, err := service.MakeMove(1, 1)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if numMoves := snapshot.NumMoves; 2 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
+
+	if player := snapshot.Player; 1 != player {
+		t.Error("unexpected player:", player)
+	}
+
+	if mode := snapshot.Mode; "bot" != mode {
+		t.Error("unexpected mode:", mode)
+	}
+
+	snapshot, err = service.Undo(0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if numMoves := len(snapshot.History); 0 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+
+	snapshot, err = service.Redo(0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if numMoves := len(snapshot.History); 2 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+}
+
+func TestGameService_Bot_Opens(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	service := NewGameService(game)
+
+	snapshot, err := service.SetBot(ai.NewPlayer(ai.Random), 1)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if numMoves := len(snapshot.History); 1 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+
+	if _, err := service.Undo(0); err == nil || "nothing to undo" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if numMoves := len(service.Snapshot().History); 1 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+
+	snapshot, _ = service.Reset()
+
+	if numMoves := len(snapshot.History); 1 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+}
+
+func TestGameService_Bot_PerfectNeverLoses(t *testing.T) {
+	random := ai.NewPlayer(ai.Random)
+
+	for i := 0; i < 20; i++ {
+		game, _ := NewGame(3, 3, 3)
+		service := NewGameService(game)
+
+		snapshot, _ := service.SetBot(ai.NewPlayer(ai.Perfect), 1+i%2)
+
+		for snapshot.Status == StatusAlive {
+			move, err := random.Move(&botState{game: snapshot.Game.Clone()})
+			if err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+
+			if snapshot, err = service.MakeMove(move.X, move.Y); err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+		}
+
+		if winner := snapshot.Winner; 0 != winner && snapshot.BotPlayer != winner {
+			t.Fatalf("%d> bot lost: %#v", i, snapshot.Board)
+		}
+	}
+}
+
+func TestGameService_Bot_LargeBoard(t *testing.T) {
+	game, _ := NewGame(15, 15, 5)
+	service := NewGameService(game)
+
+	_, _ = service.SetBot(ai.NewPlayer(ai.Perfect), 2)
+
+	for _, move := range [][2]int{{7, 7}, {8, 8}, {6, 6}} {
+		if _, err := service.MakeMove(move[0], move[1]); err != nil {
+			continue
+		}
+	}
+
+	if numMoves := len(service.Snapshot().History); numMoves < 4 {
+		t.Error("unexpected history:", numMoves)
+	}
+}
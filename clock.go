@@ -0,0 +1,136 @@
+package main
+
+import "time"
+
+// Clock limits how long the players may think.  PerMove limits every move
+// while Total is the time for the whole game, gaining the Increment after
+// every move.  Either or both may be set.  The clock starts once the first
+// move is made, so X is never timed out of a game nobody else has joined.
+type Clock struct {
+	PerMove   time.Duration    `json:"perMove"`
+	Total     time.Duration    `json:"total"`
+	Increment time.Duration    `json:"increment"`
+	Remaining [3]time.Duration `json:"remaining"`
+
+	// Started is when the player to move started thinking
+	Started time.Time `json:"started"`
+}
+
+// MaxClockTime is the longest any of the clock settings may be
+const MaxClockTime = 24 * time.Hour
+
+// SetClock limits the time each player may take, giving both their full time
+// from the next move on.  A zero perMove and total removes the clock, an
+// increment needs a total.
+func (g *Game) SetClock(perMove, total, increment time.Duration) error {
+	action, err := clockAction(perMove, total, increment)
+	if err != nil {
+		return err
+	}
+
+	g.record(action)
+
+	return nil
+}
+
+// clockAction validates the clock settings and returns the action setting them
+func clockAction(perMove, total, increment time.Duration) (Action, error) {
+	if perMove < 0 || perMove > MaxClockTime {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid time per move: %s", perMove)
+	}
+
+	if total < 0 || total > MaxClockTime {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid total time: %s", total)
+	}
+
+	if increment < 0 || increment > MaxClockTime || (increment > 0 && total == 0) {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid increment: %s", increment)
+	}
+
+	return Action{Type: ActionClock, PerMove: perMove, Total: total, Increment: increment}, nil
+}
+
+// Deadline is when the player to move runs out of time, it is false when
+// their clock is not running.
+func (g *Game) Deadline() (time.Time, bool) {
+	if g.Clock == nil || g.Status != StatusAlive || g.Clock.Started.IsZero() {
+		return time.Time{}, false
+	}
+
+	return g.Clock.Started.Add(g.Clock.allowance(g.Player)), true
+}
+
+// TimeLeft is how long the player has for their move at the given time
+func (g *Game) TimeLeft(player int, at time.Time) time.Duration {
+	if g.Clock == nil {
+		return 0
+	}
+
+	left := g.Clock.allowance(player)
+
+	if deadline, ok := g.Deadline(); ok && player == g.Player {
+		left = deadline.Sub(at)
+	}
+
+	if left < 0 {
+		return 0
+	}
+
+	return left
+}
+
+// CheckClock ends the game once the player to move has run out of time,
+// returning whether it did.
+func (g *Game) CheckClock() bool {
+	deadline, ok := g.Deadline()
+	if !ok || now().Before(deadline) {
+		return false
+	}
+
+	g.record(Action{Type: ActionTimeout, Player: g.Player})
+
+	return true
+}
+
+// allowance is how long the player may take over their move once it starts
+func (c *Clock) allowance(player int) time.Duration {
+	if c.Total == 0 || (c.PerMove > 0 && c.PerMove < c.Remaining[player]) {
+		return c.PerMove
+	}
+
+	return c.Remaining[player]
+}
+
+// reset gives both players their full time and stops the clock
+func (c *Clock) reset() {
+	c.Remaining = [3]time.Duration{0, c.Total, c.Total}
+	c.Started = time.Time{}
+}
+
+// charge takes the time the player took over their move at the given time
+// and starts the clock for the next
+func (c *Clock) charge(player int, at time.Time) {
+	if c.Total > 0 && !c.Started.IsZero() {
+		c.Remaining[player] += c.Increment - at.Sub(c.Started)
+	}
+
+	c.Started = at
+}
+
+// newClock sets up the clock from the action, no clock is kept when there
+// are no limits
+func newClock(action Action) *Clock {
+	if action.PerMove == 0 && action.Total == 0 {
+		return nil
+	}
+
+	clock := &Clock{
+		PerMove:   action.PerMove,
+		Total:     action.Total,
+		Increment: action.Increment,
+	}
+
+	clock.reset()
+
+	return clock
+}
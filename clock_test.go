@@ -0,0 +1,199 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func testClockedGame(t *testing.T, perMove, total, increment time.Duration) (*Game, *time.Time) {
+	current := time.Unix(1000, 0)
+	now = func() time.Time {
+		return current
+	}
+
+	game := testNewGame()
+
+	if err := game.SetClock(perMove, total, increment); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	return game, &current
+}
+
+func TestGame_Restart_Invalid(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	game, _ := testClockedGame(t, 10*time.Second, 0, 0)
+	_ = game.MakeMove(0, 0)
+
+	expected := game.Clone()
+	misere := true
+
+	// nothing changes when any setting is invalid, even those which are valid
+	for i, settings := range []Settings{
+		{Width: 99, Clock: &Clock{PerMove: time.Hour}, Misere: &misere},
+		{Clock: &Clock{PerMove: -time.Second}, Misere: &misere},
+		{Rules: "quantum", Clock: &Clock{}},
+	} {
+		if err := game.Restart(settings); ErrInvalidSettings != errors.Cause(err) {
+			t.Errorf("%d> unexpected err: %v", i, err)
+		}
+
+		if !reflect.DeepEqual(expected, game) {
+			t.Errorf("%d> unexpected game: %#v", i, game)
+		}
+	}
+
+	if err := game.Restart(Settings{Clock: &Clock{PerMove: time.Hour}, Misere: &misere}); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if !game.Misere || time.Hour != game.Clock.PerMove || 0 != game.NumMoves {
+		t.Errorf("unexpected game: %#v", game)
+	}
+}
+
+func TestClock_PerMove(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	game, current := testClockedGame(t, 10*time.Second, 0, 0)
+
+	// nobody is timed until the first move is made
+	*current = current.Add(time.Minute)
+
+	if game.CheckClock() {
+		t.Error("unexpected timeout")
+	}
+
+	_ = game.MakeMove(0, 0)
+
+	*current = current.Add(9 * time.Second)
+
+	if left := game.TimeLeft(2, *current); time.Second != left {
+		t.Error("unexpected time left:", left)
+	}
+
+	if left := game.TimeLeft(1, *current); 10*time.Second != left {
+		t.Error("unexpected time left:", left)
+	}
+
+	_ = game.MakeMove(1, 1)
+
+	*current = current.Add(10 * time.Second)
+
+	if err := game.MakeMove(2, 2); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if StatusTimeout != game.Status || 2 != game.Winner {
+		t.Error("unexpected result:", game.Status, game.Winner)
+	}
+
+	if err := game.Undo(); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, ok := game.Deadline(); ok {
+		t.Error("unexpected deadline")
+	}
+
+	game.Reset()
+
+	if StatusAlive != game.Status || 10*time.Second != game.TimeLeft(1, *current) {
+		t.Error("unexpected reset:", game.Status, game.TimeLeft(1, *current))
+	}
+}
+
+func TestClock_Total(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	game, current := testClockedGame(t, 0, time.Minute, 2*time.Second)
+
+	_ = game.MakeMove(0, 0)
+
+	*current = current.Add(10 * time.Second)
+	_ = game.MakeMove(1, 1)
+
+	*current = current.Add(5 * time.Second)
+	_ = game.MakeMove(2, 2)
+
+	expected := [3]time.Duration{0, 57 * time.Second, 52 * time.Second}
+	if remaining := game.Clock.Remaining; expected != remaining {
+		t.Errorf("unexpected remaining: %#v", remaining)
+	}
+
+	if deadline, ok := game.Deadline(); !ok || !current.Add(52*time.Second).Equal(deadline) {
+		t.Error("unexpected deadline:", deadline)
+	}
+
+	*current = current.Add(51 * time.Second)
+
+	if game.CheckClock() {
+		t.Error("unexpected timeout")
+	}
+
+	*current = current.Add(time.Second)
+
+	if !game.CheckClock() || 1 != game.Winner || 0 != game.Clock.Remaining[2] {
+		t.Error("expected timeout:", game.Status, game.Winner)
+	}
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game.Clock, replayed.Clock) || StatusTimeout != replayed.Status {
+		t.Errorf("unexpected replayed clock: %#v", replayed.Clock)
+	}
+}
+
+func TestClock_PerMoveAndTotal(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	game, current := testClockedGame(t, 10*time.Second, 15*time.Second, 0)
+
+	_ = game.MakeMove(0, 0)
+
+	*current = current.Add(8 * time.Second)
+	_ = game.MakeMove(1, 1)
+
+	_ = game.MakeMove(2, 2)
+
+	// only 7 of the 10 seconds a move are left on the clock
+	if left := game.TimeLeft(2, *current); 7*time.Second != left {
+		t.Error("unexpected time left:", left)
+	}
+}
+
+func TestSetClock(t *testing.T) {
+	game := testNewGame()
+
+	tests := []struct {
+		PerMove, Total, Increment time.Duration
+	}{
+		{-time.Second, 0, 0},
+		{0, -time.Second, 0},
+		{0, 0, time.Second},
+		{time.Second, 0, time.Second},
+		{0, 25 * time.Hour, 0},
+	}
+
+	for i, test := range tests {
+		if err := game.SetClock(test.PerMove, test.Total, test.Increment); ErrInvalidSettings != errors.Cause(err) {
+			t.Errorf("%d> unexpected err: %v", i, err)
+		}
+	}
+
+	_ = game.SetClock(time.Second, 0, 0)
+
+	if err := game.SetClock(0, 0, 0); err != nil || game.Clock != nil {
+		t.Error("expected clock removed:", err)
+	}
+}
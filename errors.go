@@ -0,0 +1,55 @@
+package main
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+)
+
+// Errors returned when a game refuses a request, use errors.Cause to find
+// which one an error is.
+var (
+	ErrOutOfBounds     = errors.New("out of bounds")
+	ErrCellOccupied    = errors.New("cell occupied")
+	ErrGameOver        = errors.New("game over")
+	ErrWrongTurn       = errors.New("wrong turn")
+	ErrNothingToUndo   = errors.New("nothing to undo")
+	ErrNothingToRedo   = errors.New("nothing to redo")
+	ErrInvalidSettings = errors.New("invalid settings")
+	ErrNotFound        = errors.New("not found")
+	ErrBadRequest      = errors.New("bad request")
+	ErrUnauthorized    = errors.New("unauthorized")
+	ErrWrongSeat       = errors.New("wrong seat")
+	ErrSeatTaken       = errors.New("seat taken")
+	ErrTicketClosed    = errors.New("ticket closed")
+	ErrNameTaken       = errors.New("name taken")
+	ErrNoDrawOffer     = errors.New("no draw offer")
+	ErrGameInProgress  = errors.New("game in progress")
+	ErrSeriesOver      = errors.New("series over")
+	ErrInactiveBoard   = errors.New("inactive board")
+	ErrColumnFull      = errors.New("column full")
+	ErrNotLanding      = errors.New("not landing cell")
+)
+
+// GameError describes a refused request in detail while its Cause is one of
+// the exported errors.
+type GameError struct {
+	Err error
+	Msg string
+}
+
+func newGameError(err error, format string, args ...interface{}) error {
+	return &GameError{
+		Err: err,
+		Msg: fmt.Sprintf(format, args...),
+	}
+}
+
+func (e *GameError) Error() string {
+	return e.Msg
+}
+
+// Cause returns the exported error this is a case of
+func (e *GameError) Cause() error {
+	return e.Err
+}
@@ -0,0 +1,123 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// sseKeepAlive is how often an idle event stream is sent a comment so it is
+// not closed by proxies
+const sseKeepAlive = 30 * time.Second
+
+// newEventsHandlerFunc streams the game's events as Server-Sent Events.  A
+// client reconnecting with Last-Event-ID is first sent the events it missed,
+// otherwise the stream starts with a reset event holding the current state.
+func newEventsHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		flusher, ok := w.(http.Flusher)
+		if !ok {
+			jsonErrResponse(w, errors.New("streaming unsupported"))
+			return
+		}
+
+		spectating, err := spectate(r)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		lastEventID := -1
+
+		if header := r.Header.Get("Last-Event-ID"); header != "" {
+			if lastEventID, err = strconv.Atoi(header); err != nil || lastEventID < 0 {
+				jsonErrResponse(w, newGameError(ErrBadRequest, "malformed Last-Event-ID: %s", header))
+				return
+			}
+		}
+
+		// players streaming with their token count as connected, everyone
+		// else is watching
+		seat, err := service.Authorize(bearerToken(r))
+		if err != nil || spectating {
+			seat = 0
+		}
+
+		defer service.Connect(seat)()
+
+		missed, events, unsubscribe := service.Resume(lastEventID)
+		defer unsubscribe()
+
+		w.Header().Set("Content-Type", "text/event-stream")
+		w.Header().Set("Cache-Control", "no-cache")
+		w.WriteHeader(http.StatusOK)
+
+		for _, event := range missed {
+			if err := writeEvent(w, id, event); err != nil {
+				return
+			}
+		}
+
+		flusher.Flush()
+
+		ticker := time.NewTicker(sseKeepAlive)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					// fell behind, the client resumes once it reconnects
+					return
+				}
+
+				if err := writeEvent(w, id, event); err != nil {
+					return
+				}
+			case <-ticker.C:
+				if _, err := io.WriteString(w, ": keep-alive\n\n"); err != nil {
+					return
+				}
+			case <-r.Context().Done():
+				return
+			}
+
+			flusher.Flush()
+		}
+	}
+}
+
+// writeEvent writes the event in the text/event-stream format.  Move events
+// carry the move while the others carry the state of the game, an undo is
+// sent as a reset since the state replaces whatever the client had.
+func writeEvent(w io.Writer, id string, event GameEvent) error {
+	eventType := event.Type
+
+	var data interface{} = newDefaultResponseModel(id, event.Game)
+
+	switch event.Type {
+	case EventMove:
+		data = event.Move
+	case EventUndo:
+		eventType = EventReset
+	}
+
+	payload, err := json.Marshal(data)
+	if err != nil {
+		return errors.Wrap(err, "failed to encode event")
+	}
+
+	_, err = fmt.Fprintf(w, "id: %d\nevent: %s\ndata: %s\n\n", event.ID, eventType, payload)
+
+	return err
+}
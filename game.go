@@ -1,186 +1,905 @@
 package main
 
-import "github.com/pkg/errors"
+import (
+	"time"
 
-// Game stores the state and exposes the API for playing the game
+	"github.com/pkg/errors"
+)
+
+// Game stores the state and exposes the API for playing the game by its
+// Rules, those registered under the Variant, which lay out the Board or Layers
+// and decide who wins.  History holds the accepted moves in order while Undone
+// holds the moves taken back which can still be redone.  The state is only
+// ever changed by appending actions to the Log, see ReplayGame.
 type Game struct {
-	Board    [3][3]int
-	Player   int
-	NumMoves int
-	Status   string
+	Variant     string    `json:"variant,omitempty"`
+	Board       [][]int   `json:"board"`
+	WinLength   int       `json:"winLength"`
+	Layers      [][][]int `json:"layers,omitempty"`
+	SubBoards   [][]int   `json:"subBoards,omitempty"`
+	Active      []Cell    `json:"active,omitempty"`
+	Player      int       `json:"player"`
+	NumMoves    int       `json:"numMoves"`
+	Status      string    `json:"status"`
+	Winner      int       `json:"winner"`
+	WinningLine *Line     `json:"winningLine"`
+	History     []Move    `json:"history"`
+	Undone      []Move    `json:"undone"`
+	DrawOffer   int       `json:"drawOffer"`
+	Misere      bool      `json:"misere,omitempty"`
+	Clock       *Clock    `json:"clock,omitempty"`
+	Log         []Action  `json:"log"`
 }
 
+// Action is an immutable entry in the game log.  Resets carry the variant and
+// board settings, clock actions the time limits and misère actions whether the
+// rule is on, the other actions the move made, attempted or taken back or the
+// player who ran out of time, resigned, left or answered a draw offer.
+type Action struct {
+	Type      string        `json:"type"`
+	Variant   string        `json:"variant,omitempty"`
+	Player    int           `json:"player,omitempty"`
+	X         int           `json:"x"`
+	Y         int           `json:"y"`
+	Z         int           `json:"z,omitempty"`
+	Width     int           `json:"width,omitempty"`
+	Height    int           `json:"height,omitempty"`
+	Depth     int           `json:"depth,omitempty"`
+	WinLength int           `json:"winLength,omitempty"`
+	PerMove   time.Duration `json:"perMove,omitempty"`
+	Total     time.Duration `json:"total,omitempty"`
+	Increment time.Duration `json:"increment,omitempty"`
+	Misere    bool          `json:"misere,omitempty"`
+	Reason    string        `json:"reason,omitempty"`
+	Time      time.Time     `json:"time"`
+}
+
+// Kinds of actions
+const (
+	ActionReset       = "reset"
+	ActionMove        = "move"
+	ActionRejected    = "rejected"
+	ActionUndo        = "undo"
+	ActionRedo        = "redo"
+	ActionClock       = "clock"
+	ActionTimeout     = "timeout"
+	ActionResign      = "resign"
+	ActionOfferDraw   = "offer-draw"
+	ActionAcceptDraw  = "accept-draw"
+	ActionDeclineDraw = "decline-draw"
+	ActionAbandon     = "abandon"
+	ActionMisere      = "misere"
+)
+
+// Move is a single accepted move, Z is the layer of three dimensional games
+type Move struct {
+	Player int       `json:"player"`
+	X      int       `json:"x"`
+	Y      int       `json:"y"`
+	Z      int       `json:"z,omitempty"`
+	Time   time.Time `json:"time"`
+}
+
+// Line is a run of cells on the board
+type Line struct {
+	Kind  string `json:"kind"`
+	Cells []Cell `json:"cells"`
+}
+
+// Cell is a x, y position on the board, or x, y, z on a three dimensional one
+type Cell struct {
+	X int `json:"x"`
+	Y int `json:"y"`
+	Z int `json:"z,omitempty"`
+}
+
+// Kinds of lines
+const (
+	LineColumn       = "column"
+	LineRow          = "row"
+	LineDiagonal     = "diagonal"
+	LineAntiDiagonal = "anti-diagonal"
+)
+
+var now = time.Now
+
 // Available game states
 var (
-	StatusAlive = "alive"
-	StatusDraw  = "draw"
-	StatusEnd   = "end"
+	StatusAlive      = "alive"
+	StatusDraw       = "draw"
+	StatusEnd        = "end"
+	StatusTimeout    = "timeout"
+	StatusResigned   = "resigned"
+	StatusAgreedDraw = "agreed-draw"
+	StatusAbandoned  = "abandoned"
 )
 
-// Reset sets the state to represent a new game
-func (g *Game) Reset() {
-	g.Board = [3][3]int{}
-	g.Player = 1
-	g.NumMoves = 0
-	g.Status = StatusAlive
+// Variants of the game
+const (
+	// VariantStandard is played on a single m,n,k board
+	VariantStandard = "standard"
+
+	// VariantUltimate is played on a 3x3 grid of 3x3 sub-boards.  Winning a
+	// sub-board claims it and three sub-boards in a row win the game, every
+	// move sends the opponent to the sub-board matching the cell played.
+	VariantUltimate = "ultimate"
+
+	// VariantQubic is played in a 4x4x4 cube, four in a row along any of its
+	// 76 lines wins.
+	VariantQubic = "qubic"
+
+	// VariantGravity is played as Connect Four, marks drop to the bottom of
+	// the column they are played in.
+	VariantGravity = "gravity"
+)
+
+// Board limits, the defaults are classic tic-tac-toe
+const (
+	DefaultBoardSize = 3
+	DefaultWinLength = 3
+	MaxBoardSize     = 19
+)
+
+// NewGame creates a game on a width x height board won by getting winLength
+// marks in a row.  Zero values are replaced with the defaults.
+func NewGame(width, height, winLength int) (*Game, error) {
+	return NewVariantGame(VariantStandard, width, height, winLength)
 }
 
-// MakeMove proccesses the next move at x, y.  This is the core function
-// for ensuring move validity and updating game state.
-func (g *Game) MakeMove(x, y int) error {
-	if g.Status == StatusDraw || g.Status == StatusEnd {
-		return errors.New("game over")
+// NewVariantGame creates a game of the variant, see NewGame
+func NewVariantGame(variant string, width, height, winLength int) (*Game, error) {
+	game := &Game{}
+
+	if err := game.Configure(variant, width, height, winLength); err != nil {
+		return nil, err
 	}
 
-	g.NumMoves++
+	return game, nil
+}
+
+// Width is the number of cells along the x axis
+func (g *Game) Width() int {
+	return len(g.layer(0))
+}
 
-	if err := isValidMove(g.Board, x, y); err != nil {
-		return errors.Wrap(err, "invalid move")
+// Height is the number of cells along the y axis
+func (g *Game) Height() int {
+	if board := g.layer(0); len(board) > 0 {
+		return len(board[0])
 	}
 
-	g.Board[x][y] = g.Player
+	return 0
+}
 
-	if isWin(g.Board, g.Player) {
-		g.Status = StatusEnd
-		return nil
+// Depth is the number of layers along the z axis, 1 unless the game is three
+// dimensional
+func (g *Game) Depth() int {
+	if g.Layers == nil {
+		return 1
 	}
 
-	if g.NumMoves == 9 {
-		g.Status = StatusDraw
-		return nil
+	return len(g.Layers)
+}
+
+// layer is the board of layer z, the Board itself in two dimensional games
+func (g *Game) layer(z int) [][]int {
+	if g.Layers == nil {
+		return g.Board
 	}
 
-	switch g.Player {
-	case 1:
-		g.Player = 2
-	case 2:
-		g.Player = 1
+	return g.Layers[z]
+}
+
+// Resize changes the board dimensions and win length then resets the game.
+// Zero values keep the current setting.
+func (g *Game) Resize(width, height, winLength int) error {
+	return g.Configure("", width, height, winLength)
+}
+
+// Configure changes the rules, board dimensions and win length then resets
+// the game, see Restart
+func (g *Game) Configure(variant string, width, height, winLength int) error {
+	return g.Restart(Settings{Rules: variant, Width: width, Height: height, WinLength: winLength})
+}
+
+// Settings are what a game is started over with, see Restart
+type Settings struct {
+	Rules     string
+	Width     int
+	Height    int
+	WinLength int
+
+	// Clock and Misere are left nil to keep the current ones, only the limits
+	// of the Clock are taken
+	Clock  *Clock
+	Misere *bool
+}
+
+// Restart starts the game over with the settings.  Zero values keep the
+// current setting, or fall back to the defaults of the rules when they
+// change.  Every setting is checked before any is changed so a game is left
+// as it was when one of them is invalid.  The reset is logged after the earlier
+// games, which stay in the Log, and the clock and the misère rule carry over.
+func (g *Game) Restart(settings Settings) error {
+	variant := settings.Rules
+	if variant == "" {
+		variant = g.variant()
+	}
+
+	rules, err := LookupRules(variant)
+	if err != nil {
+		return err
+	}
+
+	width, height, winLength := settings.Width, settings.Height, settings.WinLength
+
+	if variant == g.variant() {
+		if width == 0 {
+			width = g.Width()
+		}
+
+		if height == 0 {
+			height = g.Height()
+		}
+
+		if winLength == 0 {
+			winLength = g.WinLength
+		}
+	}
+
+	reset, err := rules.Setup(width, height, winLength)
+	if err != nil {
+		return err
+	}
+
+	// standard games leave the variant out of the log
+	reset.Type, reset.Variant = ActionReset, ""
+	if variant != VariantStandard {
+		reset.Variant = variant
+	}
+
+	if settings.Clock != nil {
+		clock, err := clockAction(settings.Clock.PerMove, settings.Clock.Total, settings.Clock.Increment)
+		if err != nil {
+			return err
+		}
+
+		g.record(clock)
+	}
+
+	if settings.Misere != nil {
+		g.SetMisere(*settings.Misere)
 	}
 
+	g.record(reset)
+
 	return nil
 }
 
-var isValidMove = isValidMoveFn
+// Reset sets the state to represent a new game, it fails when the rules of the
+// game are no longer registered
+func (g *Game) Reset() error {
+	return g.Configure("", 0, 0, 0)
+}
+
+// SetMisere plays the game with or without the misère rule from the next move
+// on, it carries over when the game is reset or resized.  Under the misère
+// rule whoever completes a line loses, the WinningLine is then theirs while
+// the Winner is their opponent.
+func (g *Game) SetMisere(misere bool) {
+	g.record(Action{Type: ActionMisere, Misere: misere})
+}
 
-func isValidMoveFn(board [3][3]int, x, y int) error {
-	if x < 0 || x > 2 {
-		return errors.Errorf("invalid x index: %d", x)
+// Rules are the rules the game is played by, when they are no longer
+// registered no move can be made
+func (g *Game) Rules() Rules {
+	rules, err := LookupRules(g.variant())
+	if err != nil {
+		return missingRules{name: g.variant()}
 	}
 
-	if y < 0 || y > 2 {
-		return errors.Errorf("invalid y index: %d", y)
+	return rules
+}
+
+// variant is the name of the rules being played by, Variant is left empty
+// for standard games
+func (g *Game) variant() string {
+	if g.Variant == "" {
+		return VariantStandard
 	}
 
-	if val := board[x][y]; val != 0 {
-		return errors.Errorf("space already taken: [%d][%d]: %d", x, y, val)
+	return g.Variant
+}
+
+// ReplayGame rebuilds a game by applying every action in the log in order.
+// Actions which could not have been recorded where they are, as in a corrupt
+// log, are skipped.
+func ReplayGame(log []Action) *Game {
+	game, _ := replayLog(log)
+	return game
+}
+
+// replayLog rebuilds the game as ReplayGame does and returns why the first
+// action which had to be skipped could not have been recorded
+func replayLog(log []Action) (*Game, error) {
+	game := &Game{}
+
+	var skipped error
+
+	for i, action := range log {
+		if err := game.checkAction(action); err != nil {
+			if skipped == nil {
+				skipped = errors.Wrapf(err, "invalid %s action %d", action.Type, i)
+			}
+
+			continue
+		}
+
+		game.apply(action)
 	}
 
-	return nil
+	game.Log = append([]Action(nil), log...)
+
+	return game, skipped
 }
 
-var isWin = isWinFn
+// Replay rebuilds the game started by the reset-th reset in the log, counting
+// from one, or by the last reset when zero, as it was once upto moves had been
+// made, or as it was left when upto is negative.  Moves are counted in the
+// order they were made even if they were later taken back.
+func (g *Game) Replay(reset, upto int) (*Game, error) {
+	start, end, resets := 0, len(g.Log), 0
+
+	for i, action := range g.Log {
+		if action.Type != ActionReset {
+			continue
+		}
+
+		resets++
+
+		switch {
+		case reset == 0 || resets == reset:
+			start = i
+		case resets == reset+1:
+			end = i
+		}
+	}
+
+	if reset < 0 || reset > resets {
+		return nil, newGameError(ErrBadRequest, "invalid reset: %d, %d resets made", reset, resets)
+	}
+
+	game := &Game{}
+	moves := 0
+
+	// the earlier actions still set up the clock and the misère rule
+	for _, action := range g.Log[:start] {
+		game.applyLogged(action)
+	}
+
+	for i := start; i < end; i++ {
+		action := g.Log[i]
+
+		if action.Type == ActionMove || action.Type == ActionRedo {
+			moves++
+		}
+
+		game.applyLogged(action)
 
-func isWinFn(board [3][3]int, player int) bool {
-	for i := 0; i < len(winChecks); i++ {
-		if ok := winChecks[i](board, player); ok {
-			return true
+		if moves == upto {
+			game.Log = append([]Action(nil), g.Log[:i+1]...)
+			return game, nil
 		}
 	}
 
-	return false
+	if upto < 0 {
+		game.Log = append([]Action(nil), g.Log[:end]...)
+		return game, nil
+	}
+
+	return nil, newGameError(ErrBadRequest, "invalid upto: %d, %d moves made", upto, moves)
 }
 
-// WinCheck ...
-type WinCheck func(board [3][3]int, player int) bool
+// Clone returns a deep copy of the game
+func (g *Game) Clone() *Game {
+	clone := g.state()
+	clone.Log = append([]Action(nil), g.Log...)
+
+	return clone
+}
+
+// state returns a deep copy of the game without its Log, for showing or
+// searching the game where the log isn't needed
+func (g *Game) state() *Game {
+	clone := *g
+	clone.Log = nil
+
+	if g.Board != nil {
+		clone.Board = copyBoard(g.Board)
+	}
 
-var winChecks = defaultWinChecks
+	if g.Layers != nil {
+		clone.Layers = make([][][]int, len(g.Layers))
+
+		for z := range g.Layers {
+			clone.Layers[z] = copyBoard(g.Layers[z])
+		}
+	}
+
+	if g.WinningLine != nil {
+		clone.WinningLine = &Line{
+			Kind:  g.WinningLine.Kind,
+			Cells: append([]Cell(nil), g.WinningLine.Cells...),
+		}
+	}
+
+	if g.SubBoards != nil {
+		clone.SubBoards = copyBoard(g.SubBoards)
+	}
 
-var defaultWinChecks = []WinCheck{
-	columnWinCheck,
-	rowWinCheck,
-	diagLeftToRightWinCheck,
-	diagRightToLeftWinCheck,
+	clone.Active = append([]Cell(nil), g.Active...)
+	clone.History = append([]Move(nil), g.History...)
+	clone.Undone = append([]Move(nil), g.Undone...)
+
+	if g.Clock != nil {
+		clock := *g.Clock
+		clone.Clock = &clock
+	}
+
+	return &clone
+}
+
+// MakeMove proccesses the next move at x, y.  This is the core function
+// for ensuring move validity and updating game state, only accepted moves
+// are counted in NumMoves while rejected ones are only logged.  Any undone
+// moves can no longer be redone once a new move is made.
+func (g *Game) MakeMove(x, y int) error {
+	return g.MakeMoveAs(g.Player, x, y)
 }
 
-// http://mathworld.wolfram.com/MagicSquare.html
-var magicNumberWeights = [3][3]int{
-	[3]int{8, 1, 6},
-	[3]int{3, 5, 7},
-	[3]int{4, 9, 2},
+// MakeMoveAs makes the move at x, y on behalf of player, refusing it when it
+// is not their turn.
+func (g *Game) MakeMoveAs(player, x, y int) error {
+	return g.MakeMoveAt(player, x, y, 0)
 }
 
-// columnWinCheck determines if the player has won on a column using
-// magic numbers.
-func columnWinCheck(board [3][3]int, player int) bool {
-	for y := 0; y < 3; y++ {
-		sum := 0
+// MakeMoveAt makes the move at x, y on layer z on behalf of player, see
+// MakeMoveAs
+func (g *Game) MakeMoveAt(player, x, y, z int) error {
+	g.CheckClock()
 
-		for x := 0; x < 3; x++ {
-			if board[x][y] == player {
-				sum += magicNumberWeights[x][y]
-			}
+	if err := g.checkMove(player, x, y, z); err != nil {
+		g.reject(Action{Type: ActionRejected, Player: player, X: x, Y: y, Z: z, Reason: err.Error()})
+		return err
+	}
+
+	g.record(Action{Type: ActionMove, Player: player, X: x, Y: y, Z: z})
+
+	return nil
+}
+
+// Drop makes the move in column x on behalf of player, in the cell the rules
+// land it in.  Only the rules which are a Dropper take moves by column.
+func (g *Game) Drop(player, x int) error {
+	dropper, ok := g.Rules().(Dropper)
+	if !ok {
+		return newGameError(ErrInvalidSettings, "moves by column need gravity rules: %s", g.variant())
+	}
+
+	cell := dropper.Land(g, x)
+
+	return g.MakeMoveAt(player, cell.X, cell.Y, cell.Z)
+}
+
+// Undo takes back the last move, handing the turn back to the player who
+// made it.  A game lost on time, resigned, abandoned or drawn by agreement
+// stays that way.
+func (g *Game) Undo() error {
+	if g.isDecided() {
+		return ErrGameOver
+	}
+
+	if len(g.History) == 0 {
+		return ErrNothingToUndo
+	}
+
+	move := g.History[len(g.History)-1]
+
+	g.record(Action{Type: ActionUndo, Player: move.Player, X: move.X, Y: move.Y, Z: move.Z})
+
+	return nil
+}
+
+// Redo replays the last undone move
+func (g *Game) Redo() error {
+	if len(g.Undone) == 0 {
+		return ErrNothingToRedo
+	}
+
+	move := g.Undone[len(g.Undone)-1]
+
+	if err := g.checkMove(move.Player, move.X, move.Y, move.Z); err != nil {
+		return err
+	}
+
+	g.record(Action{Type: ActionRedo, Player: move.Player, X: move.X, Y: move.Y, Z: move.Z})
+
+	return nil
+}
+
+// checkMove determines if player may move at x, y, z
+func (g *Game) checkMove(player, x, y, z int) error {
+	if g.isOver() {
+		return ErrGameOver
+	}
+
+	if player != g.Player {
+		return newGameError(ErrWrongTurn, "not player %d's turn", player)
+	}
+
+	return g.Rules().CheckMove(g, Cell{X: x, Y: y, Z: z})
+}
+
+// reject logs the rejected move, which changes nothing
+func (g *Game) reject(action Action) {
+	action.Time = now()
+
+	g.Log = append(g.Log, action)
+}
+
+// record logs the action, which must be valid, and applies it
+func (g *Game) record(action Action) {
+	action.Time = now()
+
+	g.Log = append(g.Log, action)
+	g.apply(action)
+}
+
+// applyLogged applies an action read back from a log, unless it could not
+// have been recorded at this point of the game
+func (g *Game) applyLogged(action Action) {
+	if err := g.checkAction(action); err != nil {
+		return
+	}
+
+	g.apply(action)
+}
+
+// checkAction determines if the action could have been recorded at this
+// point of the game, so a log which was corrupted or edited by hand can't
+// break it
+func (g *Game) checkAction(action Action) error {
+	switch action.Type {
+	case ActionReset:
+		variant := action.Variant
+		if variant == "" {
+			variant = VariantStandard
 		}
 
-		if sum == 15 {
-			return true
+		rules, err := LookupRules(variant)
+		if err != nil {
+			return err
+		}
+
+		reset, err := rules.Setup(action.Width, action.Height, action.WinLength)
+		if err != nil {
+			return err
+		}
+
+		if reset.Width != action.Width || reset.Height != action.Height || reset.Depth != action.Depth || reset.WinLength != action.WinLength {
+			return newGameError(ErrInvalidSettings, "invalid reset: %dx%dx%d, %d in a row", action.Width, action.Height, action.Depth, action.WinLength)
+		}
+	case ActionClock:
+		_, err := clockAction(action.PerMove, action.Total, action.Increment)
+		return err
+	case ActionMove:
+		return g.checkMove(action.Player, action.X, action.Y, action.Z)
+	case ActionRedo:
+		if len(g.Undone) == 0 {
+			return ErrNothingToRedo
+		}
+
+		return g.checkMove(action.Player, action.X, action.Y, action.Z)
+	case ActionUndo:
+		if len(g.History) == 0 {
+			return ErrNothingToUndo
+		}
+	case ActionTimeout, ActionResign, ActionAbandon, ActionOfferDraw, ActionAcceptDraw, ActionDeclineDraw:
+		if action.Player != 1 && action.Player != 2 {
+			return newGameError(ErrInvalidSettings, "invalid player: %d", action.Player)
+		}
+
+		if g.isOver() {
+			return ErrGameOver
+		}
+
+		if action.Type == ActionTimeout && g.Clock == nil {
+			return newGameError(ErrInvalidSettings, "timeout without a clock")
 		}
 	}
 
-	return false
+	return nil
 }
 
-// rowWinCheck determines if the player has won on a row using
-// magic numbers.
-func rowWinCheck(board [3][3]int, player int) bool {
-	for x := 0; x < 3; x++ {
-		sum := 0
+// apply changes the state by the action.  It is the only place the state
+// changes so folding the log through it rebuilds the game, rejected moves
+// change nothing.
+func (g *Game) apply(action Action) {
+	switch action.Type {
+	case ActionReset:
+		g.Variant = action.Variant
+		g.Board, g.Layers = nil, nil
+		g.SubBoards, g.Active = nil, nil
+		g.WinLength = action.WinLength
+		g.Player = 1
+		g.NumMoves = 0
+		g.Status = StatusAlive
+		g.Winner = 0
+		g.WinningLine = nil
+		g.History = nil
+		g.Undone = nil
+		g.DrawOffer = 0
+		g.Rules().Start(g, action)
+
+		if g.Clock != nil {
+			g.Clock.reset()
+		}
+	case ActionClock:
+		g.Clock = newClock(action)
+	case ActionMisere:
+		g.Misere = action.Misere
+	case ActionTimeout:
+		g.Status = StatusTimeout
+		g.Winner = 3 - action.Player
+		g.DrawOffer = 0
+
+		if g.Clock != nil && g.Clock.Total > 0 {
+			g.Clock.Remaining[action.Player] = 0
+		}
+	case ActionResign, ActionAbandon:
+		g.Status = StatusResigned
+		if action.Type == ActionAbandon {
+			g.Status = StatusAbandoned
+		}
 
-		for y := 0; y < 3; y++ {
-			if board[x][y] == player {
-				sum += magicNumberWeights[x][y]
-			}
+		g.Winner = 3 - action.Player
+		g.DrawOffer = 0
+	case ActionOfferDraw:
+		g.DrawOffer = action.Player
+	case ActionAcceptDraw:
+		g.Status = StatusAgreedDraw
+		g.DrawOffer = 0
+	case ActionDeclineDraw:
+		g.DrawOffer = 0
+	case ActionMove:
+		g.place(action)
+		g.Undone = nil
+	case ActionRedo:
+		g.Undone = g.Undone[:len(g.Undone)-1]
+		g.place(action)
+	case ActionUndo:
+		move := g.History[len(g.History)-1]
+
+		g.History = g.History[:len(g.History)-1]
+		g.Undone = append(g.Undone, move)
+
+		g.Rules().Revert(g, move)
+		g.Player = move.Player
+		g.NumMoves--
+		g.Status = StatusAlive
+		g.Winner = 0
+		g.WinningLine = nil
+		g.DrawOffer = 0
+
+		// the player gets their move over without the time they took back
+		if g.Clock != nil && !action.Time.IsZero() && !g.Clock.Started.IsZero() {
+			g.Clock.Started = action.Time
 		}
+	}
+}
+
+// place marks the cell for the player to move, ending the game or passing the
+// turn on.
+func (g *Game) place(action Action) {
+	// moves searched ahead by the bot are not logged or timed
+	if g.Clock != nil && !action.Time.IsZero() {
+		g.Clock.charge(g.Player, action.Time)
+	}
+
+	// moving rather than answering a draw offer declines it
+	if g.DrawOffer != g.Player {
+		g.DrawOffer = 0
+	}
+
+	move := Move{
+		Player: g.Player,
+		X:      action.X,
+		Y:      action.Y,
+		Z:      action.Z,
+		Time:   action.Time,
+	}
+
+	rules := g.Rules()
+
+	g.NumMoves++
+	g.History = append(g.History, move)
+	rules.Apply(g, move)
 
-		if sum == 15 {
-			return true
+	if line, ok := rules.Outcome(g, g.Player); ok {
+		g.Status = StatusEnd
+		g.Winner = g.Player
+		g.WinningLine = &line
+
+		// completing a line loses the misère game
+		if g.Misere {
+			g.Winner = 3 - g.Player
 		}
+
+		return
 	}
 
-	return false
+	if rules.Terminal(g) {
+		g.Status = StatusDraw
+		return
+	}
+
+	switch g.Player {
+	case 1:
+		g.Player = 2
+	case 2:
+		g.Player = 1
+	}
 }
 
-// diagLeftToRightWinCheck determines if the player has won on the left-to-right
-// diagonal line using magic numbers.
-func diagLeftToRightWinCheck(board [3][3]int, player int) bool {
-	sum := 0
+// play makes the move at x, y, z without logging it, for searching ahead
+func (g *Game) play(x, y, z int) error {
+	if err := g.checkMove(g.Player, x, y, z); err != nil {
+		return err
+	}
+
+	g.apply(Action{Type: ActionMove, Player: g.Player, X: x, Y: y, Z: z})
+
+	return nil
+}
 
-	for i := 0; i < 3; i++ {
-		if board[i][i] == player {
-			sum += magicNumberWeights[i][i]
+// takeBack undoes the last move without logging it, for searching ahead
+func (g *Game) takeBack() error {
+	if len(g.History) == 0 {
+		return ErrNothingToUndo
+	}
+
+	g.apply(Action{Type: ActionUndo})
+
+	return nil
+}
+
+func isBoardFull(board [][]int) bool {
+	for x := range board {
+		for y := range board[x] {
+			if board[x][y] == 0 {
+				return false
+			}
 		}
 	}
 
-	if sum == 15 {
-		return true
+	return true
+}
+
+func newBoard(width, height int) [][]int {
+	board := make([][]int, width)
+	for x := range board {
+		board[x] = make([]int, height)
+	}
+
+	return board
+}
+
+func copyBoard(board [][]int) [][]int {
+	// restored records may hold an empty board, or columns of other lengths
+	clone := make([][]int, len(board))
+	for x := range board {
+		clone[x] = make([]int, len(board[x]))
+		copy(clone[x], board[x])
+	}
+
+	return clone
+}
+
+func isValidMove(board [][]int, x, y int) error {
+	if x < 0 || x >= len(board) {
+		return newGameError(ErrOutOfBounds, "invalid x index: %d", x)
+	}
+
+	if y < 0 || y >= len(board[x]) {
+		return newGameError(ErrOutOfBounds, "invalid y index: %d", y)
 	}
 
-	return false
+	if val := board[x][y]; val != 0 {
+		return newGameError(ErrCellOccupied, "space already taken: [%d][%d]: %d", x, y, val)
+	}
+
+	return nil
 }
 
-// diagRightToLeftWinCheck determines if the player has won on the right-to-left
-// diagonal line using magic numbers.
-func diagRightToLeftWinCheck(board [3][3]int, player int) bool {
-	sum := 0
+// isWin runs the column, row and both diagonal win checks over the board
+func isWin(board [][]int, winLength, player int) (Line, bool) {
+	return runWinChecks(board, winLength, player, columnWinCheck, rowWinCheck, diagLeftToRightWinCheck, diagRightToLeftWinCheck)
+}
 
-	for i := 0; i < 3; i++ {
-		if board[i][2-i] == player {
-			sum += magicNumberWeights[i][2-i]
+// runWinChecks returns the line of the first check the player has won by
+func runWinChecks(board [][]int, winLength, player int, checks ...WinCheck) (Line, bool) {
+	for _, check := range checks {
+		if line, ok := check(board, winLength, player); ok {
+			return line, true
 		}
 	}
 
-	if sum == 15 {
-		return true
+	return Line{}, false
+}
+
+// WinCheck determines if the player has winLength marks in a row on the board
+// and returns the line which completed the win.
+type WinCheck func(board [][]int, winLength, player int) (Line, bool)
+
+// columnWinCheck determines if the player has won along the x axis.
+func columnWinCheck(board [][]int, winLength, player int) (Line, bool) {
+	return lineWinCheck(board, winLength, player, LineColumn, 1, 0)
+}
+
+// rowWinCheck determines if the player has won along the y axis.
+func rowWinCheck(board [][]int, winLength, player int) (Line, bool) {
+	return lineWinCheck(board, winLength, player, LineRow, 0, 1)
+}
+
+// diagLeftToRightWinCheck determines if the player has won on a diagonal
+// running from [0][0] towards [width-1][height-1].
+func diagLeftToRightWinCheck(board [][]int, winLength, player int) (Line, bool) {
+	return lineWinCheck(board, winLength, player, LineDiagonal, 1, 1)
+}
+
+// diagRightToLeftWinCheck determines if the player has won on a diagonal
+// running from [0][height-1] towards [width-1][0].
+func diagRightToLeftWinCheck(board [][]int, winLength, player int) (Line, bool) {
+	return lineWinCheck(board, winLength, player, LineAntiDiagonal, 1, -1)
+}
+
+// lineWinCheck walks the board in the dx, dy direction looking for winLength
+// consecutive cells held by the player.  The returned line covers the whole
+// run, which may be longer than winLength.
+func lineWinCheck(board [][]int, winLength, player int, kind string, dx, dy int) (Line, bool) {
+	for x := range board {
+		for y := range board[x] {
+			if !isRun(board, x, y, winLength, player, dx, dy) {
+				continue
+			}
+
+			line := Line{Kind: kind}
+
+			for cx, cy := x, y; isOnBoard(board, cx, cy) && board[cx][cy] == player; cx, cy = cx+dx, cy+dy {
+				line.Cells = append(line.Cells, Cell{X: cx, Y: cy})
+			}
+
+			return line, true
+		}
 	}
 
-	return false
+	return Line{}, false
+}
+
+// isRun determines if the winLength cells starting at x, y in the dx, dy
+// direction are all held by the player.
+func isRun(board [][]int, x, y, winLength, player, dx, dy int) bool {
+	for i := 0; i < winLength; i++ {
+		cx, cy := x+i*dx, y+i*dy
+
+		if !isOnBoard(board, cx, cy) || board[cx][cy] != player {
+			return false
+		}
+	}
+
+	return true
+}
+
+func isOnBoard(board [][]int, x, y int) bool {
+	return x >= 0 && x < len(board) && y >= 0 && y < len(board[x])
 }
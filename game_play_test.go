@@ -3,9 +3,12 @@ package main
 import (
 	"reflect"
 	"testing"
+	"time"
 )
 
 func TestPlayerOneWin(t *testing.T) {
+	defer testStubNow()()
+
 	game := &Game{}
 	game.Reset()
 
@@ -20,10 +23,18 @@ func TestPlayerOneWin(t *testing.T) {
 	_ = game.MakeMove(0, 0)
 
 	expectedGame := &Game{
-		Board:    testNew3x3Board(1, 2, 1, 1, 1, 2, 2, 2, 1),
-		Player:   1,
-		NumMoves: 9,
-		Status:   "end",
+		Board:     testNew3x3Board(1, 2, 1, 1, 1, 2, 2, 2, 1),
+		WinLength: 3,
+		Player:    1,
+		NumMoves:  9,
+		Status:    "end",
+		Winner:    1,
+		WinningLine: &Line{
+			Kind:  LineDiagonal,
+			Cells: []Cell{{X: 0, Y: 0}, {X: 1, Y: 1}, {X: 2, Y: 2}},
+		},
+		History: testHistory(1, 1, 0, 1, 1, 0, 1, 2, 0, 2, 2, 0, 2, 2, 2, 1, 0, 0),
+		Log:     testLog(1, 1, 0, 1, 1, 0, 1, 2, 0, 2, 2, 0, 2, 2, 2, 1, 0, 0),
 	}
 
 	if !reflect.DeepEqual(expectedGame, game) {
@@ -32,6 +43,8 @@ func TestPlayerOneWin(t *testing.T) {
 }
 
 func TestPlayerTwoWin(t *testing.T) {
+	defer testStubNow()()
+
 	game := &Game{}
 	game.Reset()
 
@@ -43,10 +56,18 @@ func TestPlayerTwoWin(t *testing.T) {
 	_ = game.MakeMove(2, 1)
 
 	expectedGame := &Game{
-		Board:    testNew3x3Board(1, 2, 1, 0, 2, 0, 1, 2, 0),
-		Player:   2,
-		NumMoves: 6,
-		Status:   "end",
+		Board:     testNew3x3Board(1, 2, 1, 0, 2, 0, 1, 2, 0),
+		WinLength: 3,
+		Player:    2,
+		NumMoves:  6,
+		Status:    "end",
+		Winner:    2,
+		WinningLine: &Line{
+			Kind:  LineColumn,
+			Cells: []Cell{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}},
+		},
+		History: testHistory(0, 0, 0, 1, 0, 2, 1, 1, 2, 0, 2, 1),
+		Log:     testLog(0, 0, 0, 1, 0, 2, 1, 1, 2, 0, 2, 1),
 	}
 
 	if !reflect.DeepEqual(expectedGame, game) {
@@ -55,6 +76,8 @@ func TestPlayerTwoWin(t *testing.T) {
 }
 
 func TestDraw(t *testing.T) {
+	defer testStubNow()()
+
 	game := &Game{}
 	game.Reset()
 
@@ -69,13 +92,441 @@ func TestDraw(t *testing.T) {
 	_ = game.MakeMove(1, 2)
 
 	expectedGame := &Game{
-		Board:    testNew3x3Board(1, 2, 1, 2, 1, 1, 2, 1, 2),
-		Player:   1,
-		NumMoves: 9,
-		Status:   "draw",
+		Board:     testNew3x3Board(1, 2, 1, 2, 1, 1, 2, 1, 2),
+		WinLength: 3,
+		Player:    1,
+		NumMoves:  9,
+		Status:    "draw",
+		History:   testHistory(0, 0, 0, 1, 0, 2, 1, 0, 1, 1, 2, 2, 2, 1, 2, 0, 1, 2),
+		Log:       testLog(0, 0, 0, 1, 0, 2, 1, 0, 1, 1, 2, 2, 2, 1, 2, 0, 1, 2),
+	}
+
+	if !reflect.DeepEqual(expectedGame, game) {
+		t.Errorf("unexpected game: %#v", game)
+	}
+}
+
+func TestFourInARowWin(t *testing.T) {
+	game, _ := NewGame(4, 4, 4)
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(0, 1)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(0, 2)
+	_ = game.MakeMove(2, 2)
+	_ = game.MakeMove(0, 3)
+
+	if status := game.Status; "alive" != status {
+		t.Error("unexpected status:", status)
+	}
+
+	_ = game.MakeMove(3, 3)
+
+	if status := game.Status; "end" != status {
+		t.Error("unexpected status:", status)
+	}
+
+	if player := game.Player; 1 != player {
+		t.Error("unexpected player:", player)
+	}
+}
+
+func TestRectangularDraw(t *testing.T) {
+	game, _ := NewGame(2, 1, 2)
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(1, 0)
+
+	if status := game.Status; "draw" != status {
+		t.Error("unexpected status:", status)
+	}
+}
+
+func TestUndoRedo(t *testing.T) {
+	defer testStubNow()()
+
+	game := &Game{}
+	game.Reset()
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(1, 0)
+	_ = game.MakeMove(0, 1)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(0, 2)
+
+	if status := game.Status; "end" != status {
+		t.Fatal("unexpected status:", status)
+	}
+
+	if err := game.Undo(); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+	if err := game.Undo(); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	expectedGame := &Game{
+		Board:     testNew3x3Board(1, 1, 0, 2, 0, 0, 0, 0, 0),
+		WinLength: 3,
+		Player:    2,
+		NumMoves:  3,
+		Status:    "alive",
+		History:   testHistory(0, 0, 1, 0, 0, 1),
+		Undone: []Move{
+			{Player: 1, X: 0, Y: 2, Time: testTime},
+			{Player: 2, X: 1, Y: 1, Time: testTime},
+		},
+		Log: append(testLog(0, 0, 1, 0, 0, 1, 1, 1, 0, 2),
+			Action{Type: "undo", Player: 1, X: 0, Y: 2, Time: testTime},
+			Action{Type: "undo", Player: 2, X: 1, Y: 1, Time: testTime},
+		),
+	}
+
+	if !reflect.DeepEqual(expectedGame, game) {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if err := game.Redo(); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+	if err := game.Redo(); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if err := game.Redo(); err == nil || "nothing to redo" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	expectedGame = &Game{
+		Board:     testNew3x3Board(1, 1, 1, 2, 2, 0, 0, 0, 0),
+		WinLength: 3,
+		Player:    1,
+		NumMoves:  5,
+		Status:    "end",
+		Winner:    1,
+		WinningLine: &Line{
+			Kind:  LineRow,
+			Cells: []Cell{{X: 0, Y: 0}, {X: 0, Y: 1}, {X: 0, Y: 2}},
+		},
+		History: testHistory(0, 0, 1, 0, 0, 1, 1, 1, 0, 2),
+		Undone:  []Move{},
+		Log: append(testLog(0, 0, 1, 0, 0, 1, 1, 1, 0, 2),
+			Action{Type: "undo", Player: 1, X: 0, Y: 2, Time: testTime},
+			Action{Type: "undo", Player: 2, X: 1, Y: 1, Time: testTime},
+			Action{Type: "redo", Player: 2, X: 1, Y: 1, Time: testTime},
+			Action{Type: "redo", Player: 1, X: 0, Y: 2, Time: testTime},
+		),
 	}
 
 	if !reflect.DeepEqual(expectedGame, game) {
 		t.Errorf("unexpected game: %#v", game)
 	}
 }
+
+func TestUndo_MoveClearsRedo(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	if err := game.Undo(); err == nil || "nothing to undo" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	_ = game.MakeMove(0, 0)
+	_ = game.Undo()
+	_ = game.MakeMove(1, 1)
+
+	if err := game.Redo(); err == nil || "nothing to redo" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if history := game.History; 1 != len(history) || 1 != history[0].X || 1 != history[0].Y {
+		t.Errorf("unexpected history: %#v", history)
+	}
+}
+
+var testTime = time.Unix(1500000000, 0)
+
+// testStubNow freezes the clock at testTime, the returned func restores it.
+func testStubNow() func() {
+	now = func() time.Time {
+		return testTime
+	}
+
+	return func() {
+		now = time.Now
+	}
+}
+
+// testHistory builds the history for the x, y pairs with players alternating
+// from player 1.
+func testHistory(coords ...int) []Move {
+	var history []Move
+
+	for i := 0; i+1 < len(coords); i += 2 {
+		history = append(history, Move{
+			Player: 1 + (i/2)%2,
+			X:      coords[i],
+			Y:      coords[i+1],
+			Time:   testTime,
+		})
+	}
+
+	return history
+}
+
+// testLog builds the log of a new 3x3 game followed by the moves for the x, y
+// pairs.
+func testLog(coords ...int) []Action {
+	log := []Action{{Type: "reset", Width: 3, Height: 3, WinLength: 3, Time: testTime}}
+
+	for _, move := range testHistory(coords...) {
+		log = append(log, Action{Type: "move", Player: move.Player, X: move.X, Y: move.Y, Time: testTime})
+	}
+
+	return log
+}
+
+func TestInvalidMovesNotCounted(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(3, 3)
+	_ = game.MakeMove(-1, 0)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(1, 1)
+
+	if numMoves := game.NumMoves; 2 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
+
+	if player := game.Player; 1 != player {
+		t.Error("unexpected player:", player)
+	}
+}
+
+func TestInvalidMovesDoNotDraw(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	for i := 0; i < 20; i++ {
+		_ = game.MakeMove(5, 5)
+	}
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(0, 1)
+	_ = game.MakeMove(0, 2)
+	_ = game.MakeMove(1, 0)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(2, 2)
+	_ = game.MakeMove(2, 1)
+	_ = game.MakeMove(2, 0)
+
+	if status := game.Status; "alive" != status {
+		t.Error("unexpected status:", status)
+	}
+
+	if numMoves := game.NumMoves; 8 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
+
+	_ = game.MakeMove(1, 2)
+
+	if status := game.Status; "draw" != status {
+		t.Error("unexpected status:", status)
+	}
+
+	if numMoves := game.NumMoves; 9 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
+}
+
+func TestInvalidMovesThenUndo(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(9, 9)
+	_ = game.Undo()
+
+	if numMoves := game.NumMoves; 0 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
+
+	if board := game.Board; !reflect.DeepEqual(testEmpty3x3Board(), board) {
+		t.Errorf("unexpected board: %#v", board)
+	}
+}
+
+func TestRejectedMovesLogged(t *testing.T) {
+	defer testStubNow()()
+
+	game := &Game{}
+	game.Reset()
+
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMoveAs(1, 0, 0)
+
+	expectedLog := append(testLog(1, 1),
+		Action{Type: "rejected", Player: 2, X: 1, Y: 1, Reason: "invalid move: space already taken: [1][1]: 1", Time: testTime},
+		Action{Type: "rejected", Player: 1, X: 0, Y: 0, Reason: "not player 1's turn", Time: testTime},
+	)
+
+	if log := game.Log; !reflect.DeepEqual(expectedLog, log) {
+		t.Errorf("unexpected log: %#v", log)
+	}
+}
+
+func TestReset_KeepsLog(t *testing.T) {
+	defer testStubNow()()
+
+	game := &Game{}
+	game.Reset()
+
+	_ = game.SetClock(time.Minute, 0, 0)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(1, 1)
+	game.SetMisere(true)
+	game.Reset()
+
+	// the earlier game, refused moves included, stays in the log
+	expectedLog := []Action{
+		{Type: "reset", Width: 3, Height: 3, WinLength: 3, Time: testTime},
+		{Type: "clock", PerMove: time.Minute, Time: testTime},
+		{Type: "move", Player: 1, X: 1, Y: 1, Time: testTime},
+		{Type: "rejected", Player: 2, X: 1, Y: 1, Reason: "invalid move: space already taken: [1][1]: 1", Time: testTime},
+		{Type: "misere", Misere: true, Time: testTime},
+		{Type: "reset", Width: 3, Height: 3, WinLength: 3, Time: testTime},
+	}
+
+	if log := game.Log; !reflect.DeepEqual(expectedLog, log) {
+		t.Errorf("unexpected log: %#v", log)
+	}
+
+	// the clock and the misère rule carry over
+	if game.Clock == nil || time.Minute != game.Clock.PerMove || !game.Misere || 0 != game.NumMoves {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game, replayed) {
+		t.Errorf("unexpected game: %#v", replayed)
+	}
+}
+
+func TestReplayGame(t *testing.T) {
+	game, _ := NewGame(4, 4, 3)
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(1, 1)
+	_ = game.Undo()
+	_ = game.MakeMove(2, 2)
+	_ = game.Undo()
+	_ = game.Redo()
+	_ = game.MakeMove(0, 1)
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game, replayed) {
+		t.Errorf("unexpected game: %#v", replayed)
+	}
+
+	game.Reset()
+	_ = game.MakeMove(3, 3)
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game, replayed) {
+		t.Errorf("unexpected game: %#v", replayed)
+	}
+}
+
+func TestReplayGame_Corrupt(t *testing.T) {
+	reset := Action{Type: ActionReset, Width: 3, Height: 3, WinLength: 3}
+
+	// none of these could have been recorded, they are skipped rather than
+	// breaking the game
+	for i, action := range []Action{
+		{Type: ActionTimeout, Player: 1},
+		{Type: ActionRedo, Player: 1, X: 1, Y: 1},
+		{Type: ActionUndo},
+		{Type: ActionMove, Player: 1, X: 7, Y: 7},
+		{Type: ActionMove, Player: 2, X: 1, Y: 1},
+		{Type: ActionResign, Player: 5},
+		{Type: ActionReset, Width: 0, Height: 3, WinLength: 3},
+		{Type: ActionReset, Variant: "quantum", Width: 3, Height: 3, WinLength: 3},
+	} {
+		game, err := replayLog([]Action{reset, action})
+		if err == nil {
+			t.Errorf("%d> expected err", i)
+		}
+
+		if StatusAlive != game.Status || 3 != game.Width() || 0 != game.NumMoves {
+			t.Errorf("%d> unexpected game: %#v", i, game)
+		}
+
+		if _, err := RestoreGameService(GameRecord{Game: &Game{Log: []Action{reset, action}}}); err == nil {
+			t.Errorf("%d> expected err", i)
+		}
+	}
+}
+
+func TestReplay(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	_ = game.MakeMove(5, 5)
+	_ = game.MakeMove(2, 2)
+	game.Reset()
+
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(1, 1)
+	_ = game.MakeMove(1, 1)
+	_ = game.Undo()
+	_ = game.MakeMove(2, 2)
+
+	replayed, err := game.Replay(0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if board := replayed.Board; !reflect.DeepEqual(testEmpty3x3Board(), board) {
+		t.Errorf("unexpected board: %#v", board)
+	}
+
+	replayed, _ = game.Replay(0, 2)
+
+	if board := replayed.Board; !reflect.DeepEqual(testNew3x3Board(1, 0, 0, 0, 2, 0, 0, 0, 0), board) {
+		t.Errorf("unexpected board: %#v", board)
+	}
+
+	replayed, _ = game.Replay(0, 3)
+
+	if board := replayed.Board; !reflect.DeepEqual(testNew3x3Board(1, 0, 0, 0, 0, 0, 0, 0, 2), board) {
+		t.Errorf("unexpected board: %#v", board)
+	}
+
+	if _, err := game.Replay(0, 4); err == nil || "invalid upto: 4, 3 moves made" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	// the game before the last reset can be replayed too, up to where it was
+	// left
+	replayed, _ = game.Replay(1, -1)
+
+	if board := replayed.Board; !reflect.DeepEqual(testNew3x3Board(0, 0, 0, 0, 0, 0, 0, 0, 1), board) || 2 != replayed.Player {
+		t.Errorf("unexpected game: %#v", replayed)
+	}
+
+	if replayed, _ := game.Replay(2, -1); !reflect.DeepEqual(game, replayed) {
+		t.Errorf("unexpected game: %#v", replayed)
+	}
+
+	if _, err := game.Replay(1, 2); err == nil || "invalid upto: 2, 1 moves made" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := game.Replay(3, 0); err == nil || "invalid reset: 3, 2 resets made" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+}
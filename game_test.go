@@ -15,7 +15,7 @@ func TestGame_Reset(t *testing.T) {
 		t.Fatalf("expected state to be instantiated")
 	}
 
-	expectedBoard := [3][3]int{}
+	expectedBoard := testEmpty3x3Board()
 
 	if board := game.Board; !reflect.DeepEqual(expectedBoard, board) {
 		t.Errorf("unexpected board: %#v", board)
@@ -49,23 +49,21 @@ func TestGame_MakeMove_Noop(t *testing.T) {
 }
 
 func TestGame_MakeMove_InvalidMove(t *testing.T) {
-	defer func() {
-		isValidMove = isValidMoveFn
-	}()
-
-	calledBoard := [3][3]int{}
+	var calledBoard [][]int
 	calledX := 0
 	calledY := 0
 
-	isValidMove = func(board [3][3]int, x, y int) error {
-		calledBoard = board
-		calledX = x
-		calledY = y
-		return errors.New("boom")
-	}
+	defer registerTestRules(t, stubRules{
+		checkMove: func(board [][]int, x, y int) error {
+			calledBoard = board
+			calledX = x
+			calledY = y
+			return errors.New("boom")
+		},
+	})()
 
 	board := testNew3x3Board(0, 1, 0, 0, 1, 0, 0, 0, 0)
-	game := &Game{Board: board}
+	game := &Game{Variant: "stub", Board: board}
 
 	if err := game.MakeMove(1, 2); "boom" != errors.Cause(err).Error() {
 		t.Error("unexpected err:", err)
@@ -82,29 +80,33 @@ func TestGame_MakeMove_InvalidMove(t *testing.T) {
 	if 2 != calledY {
 		t.Error("unexpected y:", calledY)
 	}
-}
 
-func TestGame_MakeMove_ReturnsOnIsWin(t *testing.T) {
-	defer func() {
-		isValidMove = isValidMoveFn
-		isWin = isWinFn
-	}()
+	if numMoves := game.NumMoves; 0 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
 
-	isValidMove = func(board [3][3]int, x, y int) error {
-		return nil
+	if history := game.History; 0 != len(history) {
+		t.Errorf("unexpected history: %#v", history)
 	}
+}
 
-	calledBoard := [3][3]int{}
+func TestGame_MakeMove_ReturnsOnIsWin(t *testing.T) {
+	var calledBoard [][]int
 	calledPlayer := 0
 
-	isWin = func(board [3][3]int, player int) bool {
-		calledBoard = board
-		calledPlayer = player
-		return true
-	}
+	defer registerTestRules(t, stubRules{
+		checkMove: func(board [][]int, x, y int) error {
+			return nil
+		},
+		outcome: func(board [][]int, winLength, player int) (Line, bool) {
+			calledBoard = copyBoard(board)
+			calledPlayer = player
+			return Line{Kind: LineRow, Cells: []Cell{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}}}, true
+		},
+	})()
 
 	board := testNew3x3Board(0, 1, 0, 0, 1, 0, 0, 0, 0)
-	game := &Game{Board: board, Player: 1}
+	game := &Game{Variant: "stub", Board: board, Player: 1}
 
 	if err := game.MakeMove(1, 2); nil != err {
 		t.Error("unexpected err:", err)
@@ -122,24 +124,29 @@ func TestGame_MakeMove_ReturnsOnIsWin(t *testing.T) {
 	if status := game.Status; "end" != status {
 		t.Error("unexpected status", status)
 	}
-}
-
-func TestGame_MakeMove_ReturnsOnIsDraw(t *testing.T) {
-	defer func() {
-		isValidMove = isValidMoveFn
-		isWin = isWinFn
-	}()
 
-	isValidMove = func(board [3][3]int, x, y int) error {
-		return nil
+	if winner := game.Winner; 1 != winner {
+		t.Error("unexpected winner", winner)
 	}
 
-	isWin = func(board [3][3]int, player int) bool {
-		return false
+	expectedLine := &Line{Kind: LineRow, Cells: []Cell{{X: 1, Y: 0}, {X: 1, Y: 1}, {X: 1, Y: 2}}}
+	if line := game.WinningLine; !reflect.DeepEqual(expectedLine, line) {
+		t.Errorf("unexpected winning line: %#v", line)
 	}
+}
+
+func TestGame_MakeMove_ReturnsOnIsDraw(t *testing.T) {
+	defer registerTestRules(t, stubRules{
+		checkMove: func(board [][]int, x, y int) error {
+			return nil
+		},
+		outcome: func(board [][]int, winLength, player int) (Line, bool) {
+			return Line{}, false
+		},
+	})()
 
 	board := testNew3x3Board(1, 2, 1, 2, 2, 1, 1, 0, 2)
-	game := &Game{Board: board, Player: 1, NumMoves: 8}
+	game := &Game{Variant: "stub", Board: board, Player: 1, NumMoves: 8}
 
 	if err := game.MakeMove(2, 1); nil != err {
 		t.Error("unexpected err:", err)
@@ -160,21 +167,17 @@ func TestGame_MakeMove_ReturnsOnIsDraw(t *testing.T) {
 }
 
 func TestGame_MakeMove_SwitchesPlayer(t *testing.T) {
-	defer func() {
-		isValidMove = isValidMoveFn
-		isWin = isWinFn
-	}()
-
-	isValidMove = func(board [3][3]int, x, y int) error {
-		return nil
-	}
-
-	isWin = func(board [3][3]int, player int) bool {
-		return false
-	}
+	defer registerTestRules(t, stubRules{
+		checkMove: func(board [][]int, x, y int) error {
+			return nil
+		},
+		outcome: func(board [][]int, winLength, player int) (Line, bool) {
+			return Line{}, false
+		},
+	})()
 
 	board := testEmpty3x3Board()
-	game := &Game{Board: board, Player: 1}
+	game := &Game{Variant: "stub", Board: board, Player: 1}
 
 	if err := game.MakeMove(2, 1); nil != err {
 		t.Error("unexpected err:", err)
@@ -193,15 +196,15 @@ func TestGame_MakeMove_SwitchesPlayer(t *testing.T) {
 	}
 }
 
-func testNew3x3Board(x0y0, x0y1, x0y2, x1y0, x1y1, x1y2, x2y0, x2y1, x2y2 int) [3][3]int {
-	return [3][3]int{
-		[3]int{x0y0, x0y1, x0y2},
-		[3]int{x1y0, x1y1, x1y2},
-		[3]int{x2y0, x2y1, x2y2},
+func testNew3x3Board(x0y0, x0y1, x0y2, x1y0, x1y1, x1y2, x2y0, x2y1, x2y2 int) [][]int {
+	return [][]int{
+		[]int{x0y0, x0y1, x0y2},
+		[]int{x1y0, x1y1, x1y2},
+		[]int{x2y0, x2y1, x2y2},
 	}
 }
 
-func testEmpty3x3Board() [3][3]int {
+func testEmpty3x3Board() [][]int {
 	return testNew3x3Board(0, 0, 0, 0, 0, 0, 0, 0, 0)
 }
 
@@ -231,7 +234,7 @@ func TestIsValidMove_InvalidYIndex(t *testing.T) {
 
 func TestIsValidMove_NotValidPosition(t *testing.T) {
 	tests := []struct {
-		InBoard     [3][3]int
+		InBoard     [][]int
 		InX         int
 		InY         int
 		ExpectedErr string
@@ -324,11 +327,7 @@ func TestIsValidMove_ValidPosition(t *testing.T) {
 	}
 }
 
-func TestIsWin_NoCheckers(t *testing.T) {
-	defer func() {
-		winChecks = defaultWinChecks
-	}()
-
+func TestRunWinChecks(t *testing.T) {
 	tests := []struct {
 		Checks []WinCheck
 		OK     bool
@@ -339,27 +338,27 @@ func TestIsWin_NoCheckers(t *testing.T) {
 		},
 		{
 			Checks: []WinCheck{
-				func([3][3]int, int) bool {
-					return false
+				func([][]int, int, int) (Line, bool) {
+					return Line{}, false
 				},
 			},
 			OK: false,
 		},
 		{
 			Checks: []WinCheck{
-				func([3][3]int, int) bool {
-					return true
+				func([][]int, int, int) (Line, bool) {
+					return Line{}, true
 				},
 			},
 			OK: true,
 		},
 		{
 			Checks: []WinCheck{
-				func([3][3]int, int) bool {
-					return false
+				func([][]int, int, int) (Line, bool) {
+					return Line{}, false
 				},
-				func([3][3]int, int) bool {
-					return true
+				func([][]int, int, int) (Line, bool) {
+					return Line{}, true
 				},
 			},
 			OK: true,
@@ -367,9 +366,7 @@ func TestIsWin_NoCheckers(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		winChecks = test.Checks
-
-		if ok := isWin(testEmpty3x3Board(), 0); ok != test.OK {
+		if _, ok := runWinChecks(testEmpty3x3Board(), 3, 0, test.Checks...); ok != test.OK {
 			t.Errorf("%d> unexpected win: %t", i, ok)
 		}
 	}
@@ -377,7 +374,7 @@ func TestIsWin_NoCheckers(t *testing.T) {
 
 func TestColumnWinCheck(t *testing.T) {
 	tests := []struct {
-		Board [3][3]int
+		Board [][]int
 		IsWin bool
 	}{
 		{
@@ -399,7 +396,7 @@ func TestColumnWinCheck(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		if ok := columnWinCheck(test.Board, 1); ok != test.IsWin {
+		if _, ok := columnWinCheck(test.Board, 3, 1); ok != test.IsWin {
 			t.Errorf("%d> unexpected win: %t", i, ok)
 		}
 	}
@@ -407,7 +404,7 @@ func TestColumnWinCheck(t *testing.T) {
 
 func TestRowWinCheck(t *testing.T) {
 	tests := []struct {
-		Board [3][3]int
+		Board [][]int
 		IsWin bool
 	}{
 		{
@@ -429,7 +426,7 @@ func TestRowWinCheck(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		if ok := rowWinCheck(test.Board, 1); ok != test.IsWin {
+		if _, ok := rowWinCheck(test.Board, 3, 1); ok != test.IsWin {
 			t.Errorf("%d> unexpected win: %t", i, ok)
 		}
 	}
@@ -437,7 +434,7 @@ func TestRowWinCheck(t *testing.T) {
 
 func TestDiagLeftToRightWinCheck(t *testing.T) {
 	tests := []struct {
-		Board [3][3]int
+		Board [][]int
 		IsWin bool
 	}{
 		{
@@ -455,7 +452,7 @@ func TestDiagLeftToRightWinCheck(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		if ok := diagLeftToRightWinCheck(test.Board, 1); ok != test.IsWin {
+		if _, ok := diagLeftToRightWinCheck(test.Board, 3, 1); ok != test.IsWin {
 			t.Errorf("%d> unexpected win: %t", i, ok)
 		}
 	}
@@ -463,7 +460,7 @@ func TestDiagLeftToRightWinCheck(t *testing.T) {
 
 func TestDiagRightToLeftWinCheck(t *testing.T) {
 	tests := []struct {
-		Board [3][3]int
+		Board [][]int
 		IsWin bool
 	}{
 		{
@@ -481,8 +478,325 @@ func TestDiagRightToLeftWinCheck(t *testing.T) {
 	}
 
 	for i, test := range tests {
-		if ok := diagRightToLeftWinCheck(test.Board, 1); ok != test.IsWin {
+		if _, ok := diagRightToLeftWinCheck(test.Board, 3, 1); ok != test.IsWin {
+			t.Errorf("%d> unexpected win: %t", i, ok)
+		}
+	}
+}
+
+func TestNewGame(t *testing.T) {
+	game, err := NewGame(0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if board := game.Board; !reflect.DeepEqual(testEmpty3x3Board(), board) {
+		t.Errorf("unexpected board: %#v", board)
+	}
+
+	if winLength := game.WinLength; 3 != winLength {
+		t.Error("unexpected winLength:", winLength)
+	}
+
+	game, err = NewGame(7, 5, 4)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if width, height := game.Width(), game.Height(); 7 != width || 5 != height {
+		t.Errorf("unexpected dimensions: %dx%d", width, height)
+	}
+
+	if status := game.Status; "alive" != status {
+		t.Error("unexpected status:", status)
+	}
+}
+
+func TestNewGame_Invalid(t *testing.T) {
+	tests := []struct {
+		Width       int
+		Height      int
+		WinLength   int
+		ExpectedErr string
+	}{
+		{Width: -1, Height: 3, WinLength: 3, ExpectedErr: "invalid width: -1"},
+		{Width: 20, Height: 3, WinLength: 3, ExpectedErr: "invalid width: 20"},
+		{Width: 3, Height: -1, WinLength: 3, ExpectedErr: "invalid height: -1"},
+		{Width: 3, Height: 20, WinLength: 3, ExpectedErr: "invalid height: 20"},
+		{Width: 3, Height: 3, WinLength: -1, ExpectedErr: "invalid win length: -1"},
+		{Width: 3, Height: 4, WinLength: 5, ExpectedErr: "invalid win length: 5"},
+	}
+
+	for i, test := range tests {
+		if _, err := NewGame(test.Width, test.Height, test.WinLength); err == nil || err.Error() != test.ExpectedErr {
+			t.Errorf("%d> unexpected err: %v", i, err)
+		}
+	}
+}
+
+func TestGame_Resize_KeepsCurrent(t *testing.T) {
+	game, _ := NewGame(5, 4, 4)
+	_ = game.MakeMove(0, 0)
+
+	if err := game.Resize(0, 6, 0); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if width, height := game.Width(), game.Height(); 5 != width || 6 != height {
+		t.Errorf("unexpected dimensions: %dx%d", width, height)
+	}
+
+	if winLength := game.WinLength; 4 != winLength {
+		t.Error("unexpected winLength:", winLength)
+	}
+
+	if numMoves := game.NumMoves; 0 != numMoves {
+		t.Error("unexpected numMoves:", numMoves)
+	}
+}
+
+func TestGame_Clone(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	clone := game.Clone()
+
+	_ = clone.MakeMove(1, 1)
+
+	if board := game.Board; !reflect.DeepEqual(testEmpty3x3Board(), board) {
+		t.Errorf("unexpected board: %#v", board)
+	}
+
+	if val := clone.Board[1][1]; 1 != val {
+		t.Error("unexpected clone cell:", val)
+	}
+
+	// an empty board, as in a restored legacy record, is copied as it is
+	empty := &Game{Board: [][]int{}, SubBoards: [][]int{}}
+
+	if clone := empty.Clone(); !reflect.DeepEqual(empty, clone) {
+		t.Errorf("unexpected clone: %#v", clone)
+	}
+}
+
+func TestIsValidMove_Rectangular(t *testing.T) {
+	board := newBoard(5, 2)
+
+	if err := isValidMove(board, 4, 1); err != nil {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := isValidMove(board, 5, 0); err.Error() != "invalid x index: 5" {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := isValidMove(board, 0, 2); err.Error() != "invalid y index: 2" {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestWinChecks_WinLength(t *testing.T) {
+	tests := []struct {
+		Check     WinCheck
+		Cells     [][2]int
+		WinLength int
+		IsWin     bool
+	}{
+		{
+			Check:     columnWinCheck,
+			Cells:     [][2]int{{1, 2}, {2, 2}, {3, 2}, {4, 2}},
+			WinLength: 4,
+			IsWin:     true,
+		},
+		{
+			Check:     columnWinCheck,
+			Cells:     [][2]int{{1, 2}, {2, 2}, {4, 2}, {5, 2}},
+			WinLength: 4,
+			IsWin:     false,
+		},
+		{
+			Check:     rowWinCheck,
+			Cells:     [][2]int{{3, 0}, {3, 1}, {3, 2}, {3, 3}, {3, 4}},
+			WinLength: 5,
+			IsWin:     true,
+		},
+		{
+			Check:     rowWinCheck,
+			Cells:     [][2]int{{3, 0}, {3, 1}, {3, 2}, {3, 3}},
+			WinLength: 5,
+			IsWin:     false,
+		},
+		{
+			Check:     diagLeftToRightWinCheck,
+			Cells:     [][2]int{{2, 1}, {3, 2}, {4, 3}, {5, 4}},
+			WinLength: 4,
+			IsWin:     true,
+		},
+		{
+			Check:     diagRightToLeftWinCheck,
+			Cells:     [][2]int{{2, 4}, {3, 3}, {4, 2}, {5, 1}},
+			WinLength: 4,
+			IsWin:     true,
+		},
+		{
+			Check:     diagRightToLeftWinCheck,
+			Cells:     [][2]int{{2, 1}, {3, 2}, {4, 3}, {5, 4}},
+			WinLength: 4,
+			IsWin:     false,
+		},
+	}
+
+	for i, test := range tests {
+		board := newBoard(7, 5)
+		for _, cell := range test.Cells {
+			board[cell[0]][cell[1]] = 1
+		}
+
+		if _, ok := test.Check(board, test.WinLength, 1); ok != test.IsWin {
 			t.Errorf("%d> unexpected win: %t", i, ok)
 		}
 	}
 }
+
+func TestWinChecks_Line(t *testing.T) {
+	tests := []struct {
+		Check        WinCheck
+		Cells        [][2]int
+		ExpectedLine Line
+	}{
+		{
+			Check: columnWinCheck,
+			Cells: [][2]int{{0, 1}, {1, 1}, {2, 1}, {3, 1}},
+			ExpectedLine: Line{
+				Kind:  LineColumn,
+				Cells: []Cell{{X: 0, Y: 1}, {X: 1, Y: 1}, {X: 2, Y: 1}, {X: 3, Y: 1}},
+			},
+		},
+		{
+			Check: rowWinCheck,
+			Cells: [][2]int{{2, 0}, {2, 1}, {2, 2}},
+			ExpectedLine: Line{
+				Kind:  LineRow,
+				Cells: []Cell{{X: 2, Y: 0}, {X: 2, Y: 1}, {X: 2, Y: 2}},
+			},
+		},
+		{
+			Check: diagLeftToRightWinCheck,
+			Cells: [][2]int{{1, 0}, {2, 1}, {3, 2}},
+			ExpectedLine: Line{
+				Kind:  LineDiagonal,
+				Cells: []Cell{{X: 1, Y: 0}, {X: 2, Y: 1}, {X: 3, Y: 2}},
+			},
+		},
+		{
+			Check: diagRightToLeftWinCheck,
+			Cells: [][2]int{{0, 3}, {1, 2}, {2, 1}},
+			ExpectedLine: Line{
+				Kind:  LineAntiDiagonal,
+				Cells: []Cell{{X: 0, Y: 3}, {X: 1, Y: 2}, {X: 2, Y: 1}},
+			},
+		},
+	}
+
+	for i, test := range tests {
+		board := newBoard(5, 4)
+		for _, cell := range test.Cells {
+			board[cell[0]][cell[1]] = 1
+		}
+
+		line, ok := test.Check(board, 3, 1)
+		if !ok {
+			t.Errorf("%d> expected win", i)
+		}
+
+		if !reflect.DeepEqual(test.ExpectedLine, line) {
+			t.Errorf("%d> unexpected line: %#v", i, line)
+		}
+	}
+}
+
+func TestGame_Errors(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	tests := []struct {
+		Play     func() error
+		Expected error
+		Msg      string
+	}{
+		{
+			Play:     func() error { return game.Undo() },
+			Expected: ErrNothingToUndo,
+			Msg:      "nothing to undo",
+		},
+		{
+			Play:     func() error { return game.Redo() },
+			Expected: ErrNothingToRedo,
+			Msg:      "nothing to redo",
+		},
+		{
+			Play:     func() error { return game.MakeMove(3, 0) },
+			Expected: ErrOutOfBounds,
+			Msg:      "invalid move: invalid x index: 3",
+		},
+		{
+			Play:     func() error { return game.MakeMove(0, -1) },
+			Expected: ErrOutOfBounds,
+			Msg:      "invalid move: invalid y index: -1",
+		},
+		{
+			Play: func() error {
+				_ = game.MakeMove(1, 1)
+				return game.MakeMove(1, 1)
+			},
+			Expected: ErrCellOccupied,
+			Msg:      "invalid move: space already taken: [1][1]: 1",
+		},
+		{
+			Play:     func() error { return game.MakeMoveAs(1, 0, 0) },
+			Expected: ErrWrongTurn,
+			Msg:      "not player 1's turn",
+		},
+		{
+			Play:     func() error { return game.Resize(0, 0, 4) },
+			Expected: ErrInvalidSettings,
+			Msg:      "invalid win length: 4",
+		},
+		{
+			Play: func() error {
+				game.Status = StatusEnd
+				return game.MakeMoveAs(2, 0, 0)
+			},
+			Expected: ErrGameOver,
+			Msg:      "game over",
+		},
+	}
+
+	for i, test := range tests {
+		err := test.Play()
+
+		if cause := errors.Cause(err); test.Expected != cause {
+			t.Errorf("%d> unexpected cause: %v", i, cause)
+		}
+
+		if err == nil || test.Msg != err.Error() {
+			t.Errorf("%d> unexpected err: %v", i, err)
+		}
+	}
+}
+
+func TestIsBoardFull(t *testing.T) {
+	tests := []struct {
+		Board [][]int
+		Full  bool
+	}{
+		{Board: testEmpty3x3Board(), Full: false},
+		{Board: testNew3x3Board(1, 2, 1, 2, 1, 2, 2, 1, 0), Full: false},
+		{Board: testNew3x3Board(1, 2, 1, 2, 1, 2, 2, 1, 2), Full: true},
+		{Board: [][]int{{1, 2, 1, 2}}, Full: true},
+	}
+
+	for i, test := range tests {
+		if full := isBoardFull(test.Board); full != test.Full {
+			t.Errorf("%d> unexpected full: %t", i, full)
+		}
+	}
+}
@@ -0,0 +1,173 @@
+package main
+
+import "github.com/pkg/errors"
+
+// Gravity boards default to the 7x6 board of Connect Four, where four in a
+// row wins
+const (
+	GravityWidth     = 7
+	GravityHeight    = 6
+	GravityWinLength = 4
+)
+
+// GravityRules play VariantGravity, marks drop to the lowest empty cell of
+// their column, the bottom of the board being its last row.  A move may only
+// be made in the cell a mark dropped in the column would land in, or by the
+// column alone.
+type GravityRules struct {
+	StandardRules
+}
+
+// Name is VariantGravity
+func (GravityRules) Name() string {
+	return VariantGravity
+}
+
+// Setup takes the defaults of Connect Four
+func (GravityRules) Setup(width, height, winLength int) (Action, error) {
+	if width == 0 {
+		width = GravityWidth
+	}
+
+	if height == 0 {
+		height = GravityHeight
+	}
+
+	if winLength == 0 {
+		winLength = GravityWinLength
+	}
+
+	return StandardRules{}.Setup(width, height, winLength)
+}
+
+// Moves lists the cell each column which isn't full would be filled at
+func (r GravityRules) Moves(game *Game) []Cell {
+	var cells []Cell
+
+	for x := range game.Board {
+		if cell := r.Land(game, x); cell.Y >= 0 {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells
+}
+
+// CheckMove refuses full columns and cells a mark wouldn't land in
+func (r GravityRules) CheckMove(game *Game, cell Cell) error {
+	if cell.Z != 0 {
+		return errors.Wrap(newGameError(ErrOutOfBounds, "invalid z index: %d", cell.Z), "invalid move")
+	}
+
+	if cell.X < 0 || cell.X >= len(game.Board) {
+		return errors.Wrap(newGameError(ErrOutOfBounds, "invalid x index: %d", cell.X), "invalid move")
+	}
+
+	landing := r.Land(game, cell.X)
+
+	if landing.Y < 0 {
+		return errors.Wrap(newGameError(ErrColumnFull, "column full: %d", cell.X), "invalid move")
+	}
+
+	if cell.Y != landing.Y {
+		return errors.Wrap(newGameError(ErrNotLanding, "column %d lands at y index %d not %d", cell.X, landing.Y, cell.Y), "invalid move")
+	}
+
+	return nil
+}
+
+// Land is the lowest empty cell of the column
+func (GravityRules) Land(game *Game, x int) Cell {
+	return Cell{X: x, Y: dropRow(game.Board, x)}
+}
+
+// Outcome only looks along the lines through the last move, as the game
+// ends as soon as a line is completed no other line can have been
+func (GravityRules) Outcome(game *Game, player int) (Line, bool) {
+	if len(game.History) == 0 {
+		return Line{}, false
+	}
+
+	move := game.History[len(game.History)-1]
+
+	return isWinAt(game.Board, game.WinLength, player, move.X, move.Y)
+}
+
+// Terminal determines if every column is full
+func (GravityRules) Terminal(game *Game) bool {
+	for x := range game.Board {
+		if game.Board[x][0] == 0 {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OrderMoves searches every column nearest the centre first, there is only a
+// move a column to begin with
+func (GravityRules) OrderMoves(game *Game, moves []Cell) []Cell {
+	sortByCentre(game, moves)
+	return moves
+}
+
+// SearchDepth looks further ahead than on a standard board of the same size
+// as there is only a move a column to search
+func (GravityRules) SearchDepth(game *Game) int {
+	if game.Width() <= GravityWidth {
+		return 6
+	}
+
+	return 4
+}
+
+// dropRow is the row a mark dropped in column x lands in, -1 when the column
+// is full or off the board
+func dropRow(board [][]int, x int) int {
+	if x < 0 || x >= len(board) {
+		return -1
+	}
+
+	for y := len(board[x]) - 1; y >= 0; y-- {
+		if board[x][y] == 0 {
+			return y
+		}
+	}
+
+	return -1
+}
+
+// isWinAt determines if the player has winLength marks in a row through x, y
+// and returns the whole run, checking only the four lines through the cell.
+func isWinAt(board [][]int, winLength, player, x, y int) (Line, bool) {
+	if !isOnBoard(board, x, y) || board[x][y] != player {
+		return Line{}, false
+	}
+
+	for _, dir := range []struct {
+		kind   string
+		dx, dy int
+	}{
+		{LineColumn, 1, 0},
+		{LineRow, 0, 1},
+		{LineDiagonal, 1, 1},
+		{LineAntiDiagonal, 1, -1},
+	} {
+		sx, sy := x, y
+		for isOnBoard(board, sx-dir.dx, sy-dir.dy) && board[sx-dir.dx][sy-dir.dy] == player {
+			sx, sy = sx-dir.dx, sy-dir.dy
+		}
+
+		line := Line{Kind: dir.kind}
+
+		for cx, cy := sx, sy; isOnBoard(board, cx, cy) && board[cx][cy] == player; cx, cy = cx+dir.dx, cy+dir.dy {
+			line.Cells = append(line.Cells, Cell{X: cx, Y: cy})
+		}
+
+		if len(line.Cells) >= winLength {
+			return line, true
+		}
+	}
+
+	return Line{}, false
+}
@@ -0,0 +1,173 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+	"github.com/pkg/errors"
+)
+
+func TestGame_Gravity(t *testing.T) {
+	game, err := NewVariantGame(VariantGravity, 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if GravityWidth != game.Width() || GravityHeight != game.Height() || GravityWinLength != game.WinLength {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	// marks land on the bottom row, or on top of those already there
+	if err := game.Drop(1, 3); err != nil || 1 != game.Board[3][5] {
+		t.Errorf("unexpected board: %v %#v", err, game.Board)
+	}
+
+	if err := game.Drop(2, 3); err != nil || 2 != game.Board[3][4] {
+		t.Errorf("unexpected board: %v %#v", err, game.Board)
+	}
+
+	if err := game.MakeMove(0, 0); ErrNotLanding != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.MakeMove(0, 5); err != nil {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.Drop(2, 7); ErrOutOfBounds != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	for i := 0; i < 4; i++ {
+		_ = game.Drop(game.Player, 3)
+	}
+
+	if err := game.Drop(2, 3); ErrColumnFull != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if rejected := game.Log[len(game.Log)-1]; ActionRejected != rejected.Type {
+		t.Errorf("unexpected log: %#v", rejected)
+	}
+
+	_ = game.Undo()
+
+	if 0 != game.Board[3][0] || 1 != game.Player {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game.Board, replayed.Board) {
+		t.Error("unexpected replay:", replayed.Board)
+	}
+}
+
+func TestGame_Drop_NotGravity(t *testing.T) {
+	for _, variant := range []string{VariantStandard, VariantUltimate, VariantQubic} {
+		game, _ := NewVariantGame(variant, 0, 0, 0)
+
+		if err := game.Drop(1, 1); ErrInvalidSettings != errors.Cause(err) {
+			t.Errorf("%s> unexpected err: %v", variant, err)
+		}
+
+		if 0 != game.NumMoves {
+			t.Errorf("%s> unexpected game: %#v", variant, game)
+		}
+	}
+}
+
+func TestGame_Gravity_Win(t *testing.T) {
+	game, _ := NewVariantGame(VariantGravity, 0, 0, 0)
+
+	// X builds a diagonal up from the bottom left while O stacks the columns
+	for _, column := range []int{0, 1, 1, 2, 2, 3, 2, 3, 3, 6} {
+		if err := game.Drop(game.Player, column); err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+	}
+
+	if StatusAlive != game.Status {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	_ = game.Drop(1, 3)
+
+	expected := Line{Kind: LineAntiDiagonal, Cells: []Cell{{X: 0, Y: 5}, {X: 1, Y: 4}, {X: 2, Y: 3}, {X: 3, Y: 2}}}
+
+	if StatusEnd != game.Status || 1 != game.Winner || nil == game.WinningLine || !reflect.DeepEqual(expected, *game.WinningLine) {
+		t.Errorf("unexpected game: %#v %#v", game.Status, game.WinningLine)
+	}
+}
+
+func TestIsWinAt(t *testing.T) {
+	board := newBoard(7, 6)
+
+	for x := 1; x < 6; x++ {
+		board[x][5] = 2
+	}
+
+	if line, ok := isWinAt(board, 4, 2, 3, 5); !ok || LineColumn != line.Kind || 5 != len(line.Cells) {
+		t.Errorf("unexpected line: %#v", line)
+	}
+
+	if _, ok := isWinAt(board, 4, 1, 3, 5); ok {
+		t.Error("unexpected win")
+	}
+
+	// the whole run is found from any cell of it, as the win checks find it
+	if line, ok := isWinAt(board, 4, 2, 5, 5); !ok || !reflect.DeepEqual(testWinLine(board, 4, 2), line) {
+		t.Errorf("unexpected line: %#v", line)
+	}
+}
+
+func testWinLine(board [][]int, winLength, player int) Line {
+	line, _ := isWin(board, winLength, player)
+	return line
+}
+
+func TestBotState_Gravity(t *testing.T) {
+	game, _ := NewVariantGame(VariantGravity, 0, 0, 0)
+
+	// X has three along the bottom row, open at both ends
+	for _, column := range []int{2, 2, 3, 3, 4, 4} {
+		_ = game.Drop(game.Player, column)
+	}
+
+	if moves := (&botState{game: game}).Moves(); 7 != len(moves) {
+		t.Errorf("unexpected moves: %#v", moves)
+	}
+
+	bot := ai.NewPlayer(ai.Perfect)
+	bot.MaxDepth = botDepth(game)
+
+	if move, err := bot.Move(&botState{game: game}); err != nil || 5 != move.Y || (1 != move.X && 5 != move.X) {
+		t.Error("unexpected move:", move, err)
+	}
+}
+
+func TestGameService_Bot_Gravity(t *testing.T) {
+	game, _ := NewVariantGame(VariantGravity, 0, 0, 0)
+	service := NewGameService(game)
+
+	started := time.Now()
+
+	snapshot, err := service.SetBot(ai.NewPlayer(ai.Perfect), 1)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	for i := 0; i < 3 && snapshot.Status == StatusAlive; i++ {
+		if snapshot, err = service.Drop(0, i); err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+	}
+
+	if numMoves := len(snapshot.History); 7 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Error("unexpected elapsed:", elapsed)
+	}
+}
@@ -1,19 +1,28 @@
 package main
 
 import (
+	"bufio"
+	"context"
 	"encoding/json"
+	"io"
 	"log"
+	"net"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
+	"github.com/kris-runzer/tick-dock-toe/ai"
 	"github.com/kris-runzer/tick-dock-toe/assets"
+	"github.com/pkg/errors"
 )
 
 // HTTP Methods
 const (
-	MethodGet  = "GET"
-	MethodPost = "POST"
-	MethodPut  = "PUT"
+	MethodGet    = "GET"
+	MethodPost   = "POST"
+	MethodPut    = "PUT"
+	MethodDelete = "DELETE"
 )
 
 func newLoggingMiddlewareHandlerFunc(next http.HandlerFunc) http.HandlerFunc {
@@ -49,20 +58,1271 @@ func (w *statusResponseWriter) WriteHeader(status int) {
 	w.ResponseWriter.WriteHeader(status)
 }
 
-func newNewGameHandlerFunc(game *Game) http.HandlerFunc {
+// Flush sends the buffered data to the client so events can be streamed
+func (w *statusResponseWriter) Flush() {
+	if flusher, ok := w.ResponseWriter.(http.Flusher); ok {
+		flusher.Flush()
+	}
+}
+
+// Hijack lets the WebSocket handler take over the connection, which is logged
+// as switching protocols.
+func (w *statusResponseWriter) Hijack() (net.Conn, *bufio.ReadWriter, error) {
+	hijacker, ok := w.ResponseWriter.(http.Hijacker)
+	if !ok {
+		return nil, nil, errors.New("response writer cannot be hijacked")
+	}
+
+	w.Status = http.StatusSwitchingProtocols
+
+	return hijacker.Hijack()
+}
+
+func newGamesHandlerFunc(registry *Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		id, action := splitGamePath(r.URL.Path)
+
+		if id == "" {
+			switch r.Method {
+			case MethodGet:
+				newListGamesHandlerFunc(registry)(w, r)
+			case MethodPost:
+				newCreateGameHandlerFunc(registry)(w, r)
+			default:
+				w.WriteHeader(http.StatusMethodNotAllowed)
+			}
+			return
+		}
+
+		service, ok := registry.Get(id)
+		if !ok {
+			jsonErrResponse(w, newGameError(ErrNotFound, "game not found: %s", id))
+			return
+		}
+
+		if r.Method != MethodGet {
+			sw := &statusResponseWriter{ResponseWriter: w}
+			w = sw
+
+			// saves whatever the request changed, refused requests are logged
+			// too but don't keep the game from being evicted
+			defer func() {
+				if sw.Status >= http.StatusBadRequest {
+					registry.Save(id)
+					return
+				}
+
+				registry.Touch(id)
+			}()
+		}
+
+		switch action {
+		case "state":
+			newStateHandlerFunc(id, service)(w, r)
+		case "move":
+			newMakeMoveHandlerFunc(id, service)(w, r)
+		case "new":
+			newNewGameHandlerFunc(id, service)(w, r)
+		case "undo":
+			newUndoHandlerFunc(id, service)(w, r)
+		case "redo":
+			newRedoHandlerFunc(id, service)(w, r)
+		case "resign":
+			newDecisionHandlerFunc(id, service, service.Resign)(w, r)
+		case "offer-draw":
+			newDecisionHandlerFunc(id, service, service.OfferDraw)(w, r)
+		case "accept-draw":
+			newDecisionHandlerFunc(id, service, service.AcceptDraw)(w, r)
+		case "decline-draw":
+			newDecisionHandlerFunc(id, service, service.DeclineDraw)(w, r)
+		case "rematch":
+			newRematchHandlerFunc(registry, id, service)(w, r)
+		case "history":
+			newHistoryHandlerFunc(id, service)(w, r)
+		case "log":
+			newLogHandlerFunc(id, service)(w, r)
+		case "replay":
+			newReplayHandlerFunc(id, service)(w, r)
+		case "ws":
+			newWebSocketHandlerFunc(registry, id, service)(w, r)
+		case "events":
+			newEventsHandlerFunc(id, service)(w, r)
+		case "join":
+			newJoinHandlerFunc(id, service, registry.Ratings)(w, r)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// Lobby long-poll limits
+const (
+	DefaultLobbyWait = 30 * time.Second
+	MaxLobbyWait     = time.Minute
+)
+
+func newLobbyHandlerFunc(lobby *Lobby) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		path := strings.Trim(strings.TrimPrefix(r.URL.Path, "/lobby"), "/")
+
+		switch {
+		case path == "queue":
+			newQueueHandlerFunc(lobby)(w, r)
+		case strings.HasPrefix(path, "queue/"):
+			newTicketHandlerFunc(lobby, strings.TrimPrefix(path, "queue/"))(w, r)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+// PlayerModel registers a player under the name
+type PlayerModel struct {
+	Name string `json:"name"`
+}
+
+// PlayerResponseModel is a player's rating, results and rating history.  The
+// key is only given when the player is registered.
+type PlayerResponseModel struct {
+	Name    string         `json:"name"`
+	Key     string         `json:"key,omitempty"`
+	Rating  int            `json:"rating"`
+	Wins    int            `json:"wins"`
+	Losses  int            `json:"losses"`
+	Draws   int            `json:"draws"`
+	History []RatingRecord `json:"history"`
+}
+
+func newPlayerResponseModel(record PlayerRecord) PlayerResponseModel {
+	responseModel := PlayerResponseModel{
+		Name:    record.Name,
+		Rating:  record.Rating,
+		Wins:    record.Wins,
+		Losses:  record.Losses,
+		Draws:   record.Draws,
+		History: record.History,
+	}
+
+	if responseModel.History == nil {
+		responseModel.History = []RatingRecord{}
+	}
+
+	return responseModel
+}
+
+// newPlayersHandlerFunc registers players on POST /players and returns them
+// from GET /players/{name}
+func newPlayersHandlerFunc(ratings *Ratings) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		name := strings.Trim(strings.TrimPrefix(r.URL.Path, "/players"), "/")
+
+		switch {
+		case name == "" && r.Method == MethodPost:
+			var model PlayerModel
+
+			defer r.Body.Close()
+			if err := decodeOptionalBody(r, &model); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+
+			record, err := ratings.Register(model.Name)
+			if err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+
+			responseModel := newPlayerResponseModel(record)
+			responseModel.Key = record.Key
+
+			w.WriteHeader(http.StatusCreated)
+
+			if err := json.NewEncoder(w).Encode(responseModel); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		case name != "" && r.Method == MethodGet:
+			record, err := ratings.Player(name)
+			if err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+
+			if err := json.NewEncoder(w).Encode(newPlayerResponseModel(record)); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		}
+	}
+}
+
+// LeaderboardResponseModel is a player's place on the leaderboard
+type LeaderboardResponseModel struct {
+	Rank   int    `json:"rank"`
+	Name   string `json:"name"`
+	Rating int    `json:"rating"`
+	Wins   int    `json:"wins"`
+	Losses int    `json:"losses"`
+	Draws  int    `json:"draws"`
+}
+
+// rulesHandlerFunc lists the names of the rules games can be played by
+func rulesHandlerFunc(w http.ResponseWriter, r *http.Request) {
+	if r.Method != MethodGet {
+		w.WriteHeader(http.StatusMethodNotAllowed)
+		return
+	}
+
+	if err := json.NewEncoder(w).Encode(RulesNames()); err != nil {
+		jsonErrResponse(w, err)
+		return
+	}
+}
+
+// newLeaderboardHandlerFunc ranks the players by rating, players on the same
+// rating share a rank
+func newLeaderboardHandlerFunc(ratings *Ratings) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		responseModels := []LeaderboardResponseModel{}
+
+		for i, record := range ratings.Leaderboard() {
+			rank := i + 1
+			if i > 0 && record.Rating == responseModels[i-1].Rating {
+				rank = responseModels[i-1].Rank
+			}
+
+			responseModels = append(responseModels, LeaderboardResponseModel{
+				Rank:   rank,
+				Name:   record.Name,
+				Rating: record.Rating,
+				Wins:   record.Wins,
+				Losses: record.Losses,
+				Draws:  record.Draws,
+			})
+		}
+
+		if err := json.NewEncoder(w).Encode(responseModels); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// TournamentModel starts a tournament between the players, listed by seed,
+// on the board given.  Omitted board values default to classic 3x3
+// tic-tac-toe.
+type TournamentModel struct {
+	Name      string   `json:"name"`
+	Format    string   `json:"format"`
+	Players   []string `json:"players"`
+	Width     int      `json:"width"`
+	Height    int      `json:"height"`
+	WinLength int      `json:"winLength"`
+}
+
+// TournamentResponseModel is a tournament with its rounds and standings, the
+// winner is only set once it has finished
+type TournamentResponseModel struct {
+	ID        string           `json:"id"`
+	Name      string           `json:"name"`
+	Format    string           `json:"format"`
+	Status    string           `json:"status"`
+	Players   []string         `json:"players"`
+	Width     int              `json:"width"`
+	Height    int              `json:"height"`
+	WinLength int              `json:"winLength"`
+	Rounds    [][]PairingModel `json:"rounds"`
+	Standings []StandingModel  `json:"standings"`
+	Winner    string           `json:"winner,omitempty"`
+}
+
+// PairingModel is a game of a tournament round.  The result is pending until
+// the game ends, then a win, draw or, for an elimination game which kept
+// being drawn, a tiebreak won by the higher seed.  A bye has no O nor game.
+type PairingModel struct {
+	X      string `json:"x"`
+	O      string `json:"o,omitempty"`
+	Game   string `json:"game,omitempty"`
+	Result string `json:"result"`
+	Winner string `json:"winner,omitempty"`
+	Draws  int    `json:"draws"`
+}
+
+// StandingModel is a player's place in a tournament
+type StandingModel struct {
+	Rank            int     `json:"rank"`
+	Name            string  `json:"name"`
+	Seed            int     `json:"seed"`
+	Points          float64 `json:"points"`
+	Wins            int     `json:"wins"`
+	Draws           int     `json:"draws"`
+	Losses          int     `json:"losses"`
+	Byes            int     `json:"byes"`
+	Buchholz        float64 `json:"buchholz"`
+	SonnebornBerger float64 `json:"sonnebornBerger"`
+	Eliminated      bool    `json:"eliminated,omitempty"`
+}
+
+// Results of a tournament game
+const (
+	PairingPending  = "pending"
+	PairingBye      = "bye"
+	PairingWin      = "win"
+	PairingDraw     = "draw"
+	PairingTiebreak = "tiebreak"
+)
+
+func newTournamentResponseModel(record TournamentRecord) TournamentResponseModel {
+	responseModel := TournamentResponseModel{
+		ID:        record.ID,
+		Name:      record.Name,
+		Format:    record.Format,
+		Status:    record.Status,
+		Players:   record.Players,
+		Width:     record.Width,
+		Height:    record.Height,
+		WinLength: record.WinLength,
+		Rounds:    [][]PairingModel{},
+		Standings: newStandingModels(record.Standings()),
+	}
+
+	for _, round := range record.Rounds {
+		pairings := []PairingModel{}
+
+		for _, pairing := range round {
+			pairingModel := PairingModel{
+				X:      pairing.Players[1],
+				O:      pairing.Players[2],
+				Game:   pairing.GameID,
+				Result: PairingPending,
+				Draws:  pairing.Draws,
+			}
+
+			switch {
+			case !pairing.Done:
+			case pairing.Players[2] == "":
+				pairingModel.Result = PairingBye
+			case pairing.Tiebreak:
+				pairingModel.Result = PairingTiebreak
+			case pairing.Winner == 0:
+				pairingModel.Result = PairingDraw
+			default:
+				pairingModel.Result = PairingWin
+			}
+
+			if pairing.Done && pairing.Winner != 0 {
+				pairingModel.Winner = pairing.Players[pairing.Winner]
+			}
+
+			pairings = append(pairings, pairingModel)
+		}
+
+		responseModel.Rounds = append(responseModel.Rounds, pairings)
+	}
+
+	if record.Status == TournamentFinished && len(responseModel.Standings) > 0 {
+		responseModel.Winner = responseModel.Standings[0].Name
+	}
+
+	return responseModel
+}
+
+func newStandingModels(standings []Standing) []StandingModel {
+	standingModels := []StandingModel{}
+
+	for _, standing := range standings {
+		standingModels = append(standingModels, StandingModel{
+			Rank:            standing.Rank,
+			Name:            standing.Name,
+			Seed:            standing.Seed,
+			Points:          standing.Points,
+			Wins:            standing.Wins,
+			Draws:           standing.Draws,
+			Losses:          standing.Losses,
+			Byes:            standing.Byes,
+			Buchholz:        standing.Buchholz,
+			SonnebornBerger: standing.SonnebornBerger,
+			Eliminated:      standing.Eliminated,
+		})
+	}
+
+	return standingModels
+}
+
+// newTournamentsHandlerFunc starts tournaments on POST /tournaments, lists
+// them on GET /tournaments and serves each under /tournaments/{id}
+func newTournamentsHandlerFunc(tournaments *Tournaments) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		parts := strings.SplitN(strings.Trim(strings.TrimPrefix(r.URL.Path, "/tournaments"), "/"), "/", 2)
+		id, action := parts[0], ""
+
+		if len(parts) == 2 {
+			action = parts[1]
+		}
+
+		switch {
+		case id == "" && r.Method == MethodGet:
+			responseModels := []TournamentResponseModel{}
+
+			for _, record := range tournaments.List() {
+				responseModels = append(responseModels, newTournamentResponseModel(record))
+			}
+
+			if err := json.NewEncoder(w).Encode(responseModels); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		case id == "" && r.Method == MethodPost:
+			var model TournamentModel
+
+			defer r.Body.Close()
+			if err := decodeOptionalBody(r, &model); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+
+			record, err := tournaments.Create(model.Name, model.Format, model.Players, model.Width, model.Height, model.WinLength)
+			if err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+
+			w.WriteHeader(http.StatusCreated)
+
+			if err := json.NewEncoder(w).Encode(newTournamentResponseModel(record)); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		case id == "":
+			w.WriteHeader(http.StatusMethodNotAllowed)
+		case action == "":
+			newTournamentHandlerFunc(tournaments, id)(w, r)
+		case action == "standings":
+			newStandingsHandlerFunc(tournaments, id)(w, r)
+		case action == "seat":
+			newTournamentSeatHandlerFunc(tournaments, id)(w, r)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}
+}
+
+func newTournamentHandlerFunc(tournaments *Tournaments, id string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		record, err := tournaments.Get(id)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newTournamentResponseModel(record)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+func newStandingsHandlerFunc(tournaments *Tournaments, id string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		record, err := tournaments.Get(id)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newStandingModels(record.Standings())); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// newTournamentSeatHandlerFunc answers a registered player, identified by
+// their name and key, with the game they are to play next and its token
+func newTournamentSeatHandlerFunc(tournaments *Tournaments, id string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var model JoinModel
+
+		defer r.Body.Close()
+		if err := decodeOptionalBody(r, &model); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if model.Name == "" {
+			jsonErrResponse(w, newGameError(ErrUnauthorized, "missing name"))
+			return
+		}
+
+		name, err := playerName(tournaments.Registry.Ratings, model.Name, model.Key)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		gameID, seat, token, err := tournaments.Seat(id, name)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(JoinResponseModel{ID: gameID, Seat: seat, Token: token}); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// QueueModel picks the game to be matched for, omitted values default to
+// classic 3x3 tic-tac-toe.  A registered player gives their name and key to
+// have the game rated.
+type QueueModel struct {
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	WinLength int    `json:"winLength"`
+	Name      string `json:"name"`
+	Key       string `json:"key"`
+}
+
+// TicketResponseModel is a place in the lobby, the game, seat and token are
+// only set once it has been matched
+type TicketResponseModel struct {
+	Ticket    string `json:"ticket"`
+	Name      string `json:"name,omitempty"`
+	Status    string `json:"status"`
+	Width     int    `json:"width"`
+	Height    int    `json:"height"`
+	WinLength int    `json:"winLength"`
+	ID        string `json:"id,omitempty"`
+	Seat      int    `json:"seat,omitempty"`
+	Token     string `json:"token,omitempty"`
+}
+
+func newTicketResponseModel(ticket Ticket) TicketResponseModel {
+	return TicketResponseModel{
+		Ticket:    ticket.ID,
+		Name:      ticket.Name,
+		Status:    ticket.Status,
+		Width:     ticket.Width,
+		Height:    ticket.Height,
+		WinLength: ticket.WinLength,
+		ID:        ticket.GameID,
+		Seat:      ticket.Seat,
+		Token:     ticket.Token,
+	}
+}
+
+func newQueueHandlerFunc(lobby *Lobby) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var model QueueModel
+
+		defer r.Body.Close()
+		if err := decodeOptionalBody(r, &model); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		name, err := playerName(lobby.Registry.Ratings, model.Name, model.Key)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		ticket, err := lobby.QueueAs(name, model.Width, model.Height, model.WinLength)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err := json.NewEncoder(w).Encode(newTicketResponseModel(ticket)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// newTicketHandlerFunc answers with the ticket once it is no longer waiting,
+// or as it is after the wait given in seconds.  DELETE cancels the ticket.
+func newTicketHandlerFunc(lobby *Lobby, id string) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var ticket Ticket
+		var err error
+
+		switch r.Method {
+		case MethodGet:
+			wait, waitErr := lobbyWait(r)
+			if waitErr != nil {
+				jsonErrResponse(w, waitErr)
+				return
+			}
+
+			ctx, cancel := context.WithTimeout(r.Context(), wait)
+			defer cancel()
+
+			ticket, err = lobby.Wait(id, ctx.Done())
+		case MethodDelete:
+			ticket, err = lobby.Cancel(id)
+		default:
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newTicketResponseModel(ticket)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// lobbyWait reads how long to wait for the ticket from the wait query
+// parameter, in seconds
+func lobbyWait(r *http.Request) (time.Duration, error) {
+	param := r.URL.Query().Get("wait")
+	if param == "" {
+		return DefaultLobbyWait, nil
+	}
+
+	seconds, err := strconv.Atoi(param)
+	if err != nil || seconds < 0 {
+		return 0, newGameError(ErrBadRequest, "malformed wait: %s", param)
+	}
+
+	if wait := time.Duration(seconds) * time.Second; wait < MaxLobbyWait {
+		return wait, nil
+	}
+
+	return MaxLobbyWait, nil
+}
+
+// countParam reads a count from the query parameter, fallback when it is not
+// given
+func countParam(r *http.Request, name string, fallback int) (int, error) {
+	param := r.URL.Query().Get(name)
+	if param == "" {
+		return fallback, nil
+	}
+
+	count, err := strconv.Atoi(param)
+	if err != nil || count < 0 {
+		return 0, newGameError(ErrBadRequest, "malformed %s: %s", name, param)
+	}
+
+	return count, nil
+}
+
+// splitGamePath breaks /games/{id}/{action} into its id and action parts.
+func splitGamePath(path string) (string, string) {
+	parts := strings.SplitN(strings.Trim(strings.TrimPrefix(path, "/games"), "/"), "/", 2)
+
+	if len(parts) < 2 {
+		return parts[0], ""
+	}
+
+	return parts[0], parts[1]
+}
+
+func newCreateGameHandlerFunc(registry *Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		var model NewGameModel
+
+		defer r.Body.Close()
+		if err := decodeOptionalBody(r, &model); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		game := &Game{}
+
+		err := game.Restart(model.settings())
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		var bot *ai.Player
+
+		switch model.Mode {
+		case "", ModeHuman:
+		case ModeBot:
+			if bot, err = newBot(model); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		default:
+			jsonErrResponse(w, newGameError(ErrInvalidSettings, "unknown mode: %s", model.Mode))
+			return
+		}
+
+		id, service, err := registry.Create(game)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		snapshot := service.Snapshot()
+
+		if bot != nil {
+			if model.BotPlayer == 0 {
+				model.BotPlayer = 2
+			}
+
+			if snapshot, err = service.SetBot(bot, model.BotPlayer); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+
+			registry.Touch(id)
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, snapshot)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// newListGamesHandlerFunc lists the games, only those with the status given
+// in the status query parameter when there is one
+func newListGamesHandlerFunc(registry *Registry) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		status := r.URL.Query().Get("status")
+		responseModels := []DefaultResponseModel{}
+
+		for _, id := range registry.List() {
+			service, ok := registry.Get(id)
+			if !ok {
+				continue
+			}
+
+			snapshot := service.Snapshot()
+
+			if status == "" || status == snapshot.Status {
+				responseModels = append(responseModels, newDefaultResponseModel(id, snapshot))
+			}
+		}
+
+		if err := json.NewEncoder(w).Encode(responseModels); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+func newNewGameHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if _, err := service.Authorize(bearerToken(r)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		var model NewGameModel
+
+		defer r.Body.Close()
+		if err := decodeOptionalBody(r, &model); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		game, err := service.Restart(model.settings())
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, game)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+func newBot(model NewGameModel) (*ai.Player, error) {
+	if model.Difficulty == "" {
+		model.Difficulty = string(ai.Perfect)
+	}
+
+	level, err := ai.ParseLevel(model.Difficulty)
+	if err != nil {
+		return nil, newGameError(ErrInvalidSettings, "%v", err)
+	}
+
+	if model.BlunderRate < 0 || model.BlunderRate > 1 {
+		return nil, newGameError(ErrInvalidSettings, "invalid blunder rate: %g", model.BlunderRate)
+	}
+
+	bot := ai.NewPlayer(level)
+	bot.BlunderRate = model.BlunderRate
+
+	return bot, nil
+}
+
+// NewGameModel configures the rules and board of a new game, zero values fall
+// back to the current or default setting.  The mode, and the bot settings
+// which go with it, can only be chosen when the game is created.  Misere is
+// left out to keep the current rule.
+type NewGameModel struct {
+	Rules       string      `json:"rules"`
+	Misere      *bool       `json:"misere"`
+	Width       int         `json:"width"`
+	Height      int         `json:"height"`
+	WinLength   int         `json:"winLength"`
+	Mode        string      `json:"mode"`
+	Difficulty  string      `json:"difficulty"`
+	BlunderRate float64     `json:"blunderRate"`
+	BotPlayer   int         `json:"botPlayer"`
+	Clock       *ClockModel `json:"clock"`
+}
+
+// settings are what the game is started over with
+func (m NewGameModel) settings() Settings {
+	settings := Settings{
+		Rules:     m.Rules,
+		Width:     m.Width,
+		Height:    m.Height,
+		WinLength: m.WinLength,
+		Misere:    m.Misere,
+	}
+
+	if m.Clock != nil {
+		settings.Clock = m.Clock.limits()
+	}
+
+	return settings
+}
+
+// ClockModel is the time limits of a game in seconds, a game is created or
+// started over with a clock by giving its perMove, total and increment.  In
+// the state Remaining is how long each player has for their move and Running
+// the player whose clock is running, if either is.
+type ClockModel struct {
+	PerMove   float64         `json:"perMove"`
+	Total     float64         `json:"total"`
+	Increment float64         `json:"increment"`
+	Remaining map[int]float64 `json:"remaining,omitempty"`
+	Running   int             `json:"running"`
+}
+
+func newClockModel(game *Game) *ClockModel {
+	if game.Clock == nil {
+		return nil
+	}
+
+	current := now()
+
+	responseModel := &ClockModel{
+		PerMove:   game.Clock.PerMove.Seconds(),
+		Total:     game.Clock.Total.Seconds(),
+		Increment: game.Clock.Increment.Seconds(),
+		Remaining: map[int]float64{},
+	}
+
+	for player := 1; player <= 2; player++ {
+		responseModel.Remaining[player] = game.TimeLeft(player, current).Seconds()
+	}
+
+	if _, ok := game.Deadline(); ok {
+		responseModel.Running = game.Player
+	}
+
+	return responseModel
+}
+
+// limits returns a clock with the time per move, total and increment
+func (m *ClockModel) limits() *Clock {
+	seconds := func(s float64) time.Duration {
+		return time.Duration(s * float64(time.Second))
+	}
+
+	return &Clock{PerMove: seconds(m.PerMove), Total: seconds(m.Total), Increment: seconds(m.Increment)}
+}
+
+// DefaultResponseModel is return by all endpoints
+type DefaultResponseModel struct {
+	ID          string         `json:"id"`
+	Rules       string         `json:"rules"`
+	Board       [][]int        `json:"board"`
+	Width       int            `json:"width"`
+	Height      int            `json:"height"`
+	WinLength   int            `json:"winLength"`
+	Depth       int            `json:"depth,omitempty"`
+	Layers      [][][]int      `json:"layers,omitempty"`
+	SubBoards   [][]int        `json:"subBoards,omitempty"`
+	Active      []Cell         `json:"active,omitempty"`
+	Player      int            `json:"player"`
+	NumMoves    int            `json:"numMoves"`
+	Status      string         `json:"status"`
+	Misere      bool           `json:"misere"`
+	Winner      int            `json:"winner"`
+	Loser       int            `json:"loser"`
+	WinningLine *Line          `json:"winningLine"`
+	DrawOffer   int            `json:"drawOffer"`
+	CanUndo     bool           `json:"canUndo"`
+	CanRedo     bool           `json:"canRedo"`
+	Mode        string         `json:"mode"`
+	Bot         *BotModel      `json:"bot,omitempty"`
+	Seats       []int          `json:"seats"`
+	Watchers    int            `json:"watchers"`
+	Names       map[int]string `json:"names,omitempty"`
+	Clock       *ClockModel    `json:"clock,omitempty"`
+	Series      *SeriesModel   `json:"series,omitempty"`
+}
+
+// BotModel describes the computer opponent
+type BotModel struct {
+	Player     int    `json:"player"`
+	Difficulty string `json:"difficulty"`
+}
+
+func newDefaultResponseModel(id string, game GameSnapshot) DefaultResponseModel {
+	responseModel := DefaultResponseModel{
+		ID:          id,
+		Rules:       game.variant(),
+		Board:       game.Board,
+		Width:       game.Width(),
+		Height:      game.Height(),
+		WinLength:   game.WinLength,
+		Layers:      game.Layers,
+		SubBoards:   game.SubBoards,
+		Active:      game.Active,
+		Player:      game.Player,
+		NumMoves:    game.NumMoves,
+		Status:      game.Status,
+		Misere:      game.Misere,
+		Winner:      game.Winner,
+		WinningLine: game.WinningLine,
+		DrawOffer:   game.DrawOffer,
+		CanUndo:     len(game.History) > 0,
+		CanRedo:     len(game.Undone) > 0,
+		Mode:        game.Mode,
+		Seats:       game.Seats,
+		Watchers:    game.Watchers,
+		Clock:       newClockModel(&game.Game),
+		Series:      newSeriesModel(game),
+	}
+
+	if game.Layers != nil {
+		responseModel.Depth = game.Depth()
+	}
+
+	if game.Winner != 0 {
+		responseModel.Loser = 3 - game.Winner
+	}
+
+	if responseModel.Seats == nil {
+		responseModel.Seats = []int{}
+	}
+
+	for player := 1; player <= 2; player++ {
+		if name := game.Names[player]; name != "" {
+			if responseModel.Names == nil {
+				responseModel.Names = map[int]string{}
+			}
+
+			responseModel.Names[player] = name
+		}
+	}
+
+	if game.Mode == ModeBot {
+		responseModel.Bot = &BotModel{
+			Player:     game.BotPlayer,
+			Difficulty: string(game.Difficulty),
+		}
+	}
+
+	return responseModel
+}
+
+func newStateHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, service.Snapshot())); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+func newMakeMoveHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPut {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var model MoveModel
+
+		defer r.Body.Close()
+		if err := json.NewDecoder(r.Body).Decode(&model); err != nil {
+			jsonErrResponse(w, newGameError(ErrBadRequest, "malformed move: %v", err))
+			return
+		}
+
+		seat, err := service.Authorize(bearerToken(r))
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		game, err := makeMove(service, seat, model)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, game)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+func newUndoHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		seat, err := service.Authorize(bearerToken(r))
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		game, err := service.Undo(seat)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, game)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+func newRedoHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		seat, err := service.Authorize(bearerToken(r))
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		game, err := service.Redo(seat)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, game)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// DecisionModel names the player resigning or settling on a draw, which is
+// only needed before anyone has joined the game
+type DecisionModel struct {
+	Player int `json:"player,omitempty"`
+}
+
+// newDecisionHandlerFunc resigns, offers or answers a draw on behalf of the
+// seated player, or the model's player if there is one
+func newDecisionHandlerFunc(id string, service *GameService, decide func(player int) (GameSnapshot, error)) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var model DecisionModel
+
+		defer r.Body.Close()
+		if err := decodeOptionalBody(r, &model); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		seat, err := service.Authorize(bearerToken(r))
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if seat != 0 {
+			if model.Player != 0 && model.Player != seat {
+				jsonErrResponse(w, newGameError(ErrWrongSeat, "seat %d cannot decide for player %d", seat, model.Player))
+				return
+			}
+
+			model.Player = seat
+		}
+
+		game, err := decide(model.Player)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, game)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// RematchModel is how many games the series is played over, it only applies
+// to the first rematch
+type RematchModel struct {
+	BestOf int `json:"bestOf"`
+}
+
+// SeriesModel is the series the game is part of.  The score runs up to and
+// including the game, once it has ended, keyed by the seats in this game.
+// Winner is the seat of the player who won the series, if they have.
+type SeriesModel struct {
+	ID       string      `json:"id"`
+	BestOf   int         `json:"bestOf"`
+	Game     int         `json:"game"`
+	Previous string      `json:"previous,omitempty"`
+	Next     string      `json:"next,omitempty"`
+	Score    map[int]int `json:"score"`
+	Draws    int         `json:"draws"`
+	Winner   int         `json:"winner"`
+}
+
+func newSeriesModel(game GameSnapshot) *SeriesModel {
+	if game.Series == nil {
+		return nil
+	}
+
+	standing := game.Series.Standing(&game.Game)
+
+	return &SeriesModel{
+		ID:       game.Series.ID,
+		BestOf:   game.Series.BestOf,
+		Game:     game.Series.Number,
+		Previous: game.Series.Previous,
+		Next:     game.Series.Next,
+		Score:    map[int]int{1: standing[1], 2: standing[2]},
+		Draws:    standing[0],
+		Winner:   game.Series.Winner(standing),
+	}
+}
+
+// newRematchHandlerFunc starts the next game of the series, the players use
+// the same tokens for the other seat
+func newRematchHandlerFunc(registry *Registry, id string, service *GameService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != MethodPost {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 
-		game.Reset()
+		if _, err := service.Authorize(bearerToken(r)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		var model RematchModel
+
+		defer r.Body.Close()
+		if err := decodeOptionalBody(r, &model); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		next, rematch, err := registry.Rematch(id, model.BestOf)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		w.WriteHeader(http.StatusCreated)
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(next, rematch.Snapshot())); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// HistoryResponseModel lists the moves made so far in the order they were
+// played
+type HistoryResponseModel struct {
+	ID    string `json:"id"`
+	Moves []Move `json:"moves"`
+}
+
+func newHistoryHandlerFunc(id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		game := service.Snapshot()
 
-		responseModel := DefaultResponseModel{
-			Board:    game.Board,
-			Player:   game.Player,
-			NumMoves: game.NumMoves,
-			Status:   game.Status,
+		responseModel := HistoryResponseModel{
+			ID:    id,
+			Moves: game.History,
+		}
+
+		if responseModel.Moves == nil {
+			responseModel.Moves = []Move{}
 		}
 
 		if err := json.NewEncoder(w).Encode(responseModel); err != nil {
@@ -72,26 +1332,42 @@ func newNewGameHandlerFunc(game *Game) http.HandlerFunc {
 	}
 }
 
-// DefaultResponseModel is return by all endpoints
-type DefaultResponseModel struct {
-	Board    [3][3]int `json:"board"`
-	Player   int       `json:"player"`
-	NumMoves int       `json:"numMoves"`
-	Status   string    `json:"status"`
+// LogPageSize is how many actions of the log are answered at once
+const LogPageSize = 100
+
+// LogResponseModel is a page of every action taken in the game, including
+// refused moves, starting from the From-th action of the Total in the log
+type LogResponseModel struct {
+	ID      string   `json:"id"`
+	From    int      `json:"from"`
+	Total   int      `json:"total"`
+	Actions []Action `json:"actions"`
 }
 
-func newStateHandlerFunc(game *Game) http.HandlerFunc {
+func newLogHandlerFunc(id string, service *GameService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
 		if r.Method != MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 
-		responseModel := DefaultResponseModel{
-			Board:    game.Board,
-			Player:   game.Player,
-			NumMoves: game.NumMoves,
-			Status:   game.Status,
+		from, err := countParam(r, "from", 0)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		actions, total := service.Log(from, LogPageSize)
+
+		responseModel := LogResponseModel{
+			ID:      id,
+			From:    from,
+			Total:   total,
+			Actions: actions,
+		}
+
+		if responseModel.Actions == nil {
+			responseModel.Actions = []Action{}
 		}
 
 		if err := json.NewEncoder(w).Encode(responseModel); err != nil {
@@ -101,57 +1377,236 @@ func newStateHandlerFunc(game *Game) http.HandlerFunc {
 	}
 }
 
-func newMakeMoveHandlerFunc(game *Game) http.HandlerFunc {
+// newReplayHandlerFunc returns the game started by the reset-th reset, or the
+// last, as it was after the first upto moves, or as it was left when upto is
+// not given.  Without either the game is returned as it is now.
+func newReplayHandlerFunc(id string, service *GameService) http.HandlerFunc {
 	return func(w http.ResponseWriter, r *http.Request) {
-		if r.Method != MethodPut {
+		if r.Method != MethodGet {
 			w.WriteHeader(http.StatusMethodNotAllowed)
 			return
 		}
 
-		var model MoveModel
+		game := service.Snapshot()
+
+		reset, err := countParam(r, "reset", 0)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		upto, err := countParam(r, "upto", -1)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		if reset > 0 || upto >= 0 {
+			if game, err = service.Replay(reset, upto); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		}
+
+		if err := json.NewEncoder(w).Encode(newDefaultResponseModel(id, game)); err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+	}
+}
+
+// JoinModel picks the seat to join, 1 for X or 2 for O.  Zero takes
+// whichever is free.  A registered player gives their name and key to have
+// the game rated.
+type JoinModel struct {
+	Seat int    `json:"seat"`
+	Name string `json:"name"`
+	Key  string `json:"key"`
+}
+
+// JoinResponseModel is the seat joined and the token to make its moves with
+type JoinResponseModel struct {
+	ID    string `json:"id"`
+	Seat  int    `json:"seat"`
+	Token string `json:"token"`
+}
+
+func newJoinHandlerFunc(id string, service *GameService, ratings *Ratings) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodPost {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		var model JoinModel
 
 		defer r.Body.Close()
-		if err := json.NewDecoder(r.Body).Decode(&model); err != nil {
+		if err := decodeOptionalBody(r, &model); err != nil {
 			jsonErrResponse(w, err)
 			return
 		}
 
-		if err := game.MakeMove(model.X, model.Y); err != nil {
+		name, err := playerName(ratings, model.Name, model.Key)
+		if err != nil {
 			jsonErrResponse(w, err)
 			return
 		}
 
-		responseModel := DefaultResponseModel{
-			Board:    game.Board,
-			Player:   game.Player,
-			NumMoves: game.NumMoves,
-			Status:   game.Status,
+		seat, token, err := service.JoinAs(model.Seat, name)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
 		}
 
-		if err := json.NewEncoder(w).Encode(responseModel); err != nil {
+		w.WriteHeader(http.StatusCreated)
+
+		if err := json.NewEncoder(w).Encode(JoinResponseModel{ID: id, Seat: seat, Token: token}); err != nil {
 			jsonErrResponse(w, err)
 			return
 		}
 	}
 }
 
-// MoveModel represents the x,y coordinates of the move to make.
+// playerName returns the name of the registered player the key belongs to,
+// or nothing when no name is given
+func playerName(ratings *Ratings, name, key string) (string, error) {
+	if name == "" {
+		return "", nil
+	}
+
+	if ratings == nil {
+		return "", newGameError(ErrNotFound, "player not found: %s", name)
+	}
+
+	return ratings.Authorize(name, key)
+}
+
+// bearerToken returns the token from the Authorization header.  Browsers
+// can't set headers on WebSocket requests so the token query parameter is
+// used when there is no header.
+func bearerToken(r *http.Request) string {
+	const prefix = "Bearer "
+
+	if header := r.Header.Get("Authorization"); len(header) > len(prefix) && strings.EqualFold(header[:len(prefix)], prefix) {
+		return header[len(prefix):]
+	}
+
+	return r.URL.Query().Get("token")
+}
+
+// MoveModel represents the x,y coordinates of the move to make, and its z
+// layer in three dimensional games.  Gravity games can be given just the
+// Column to drop the mark in instead.  When the player is given the move is
+// refused unless it is their turn.
 type MoveModel struct {
-	X int `json:"x"`
-	Y int `json:"y"`
+	X      int  `json:"x"`
+	Y      int  `json:"y"`
+	Z      int  `json:"z"`
+	Column *int `json:"column,omitempty"`
+	Player int  `json:"player,omitempty"`
+}
+
+// makeMove makes the move on behalf of the seated player, or the model's
+// player if there is one
+func makeMove(service *GameService, seat int, model MoveModel) (GameSnapshot, error) {
+	if seat != 0 {
+		if model.Player != 0 && model.Player != seat {
+			return GameSnapshot{}, newGameError(ErrWrongSeat, "seat %d cannot move for player %d", seat, model.Player)
+		}
+
+		model.Player = seat
+	}
+
+	if model.Column != nil {
+		return service.Drop(model.Player, *model.Column)
+	}
+
+	return service.MakeMoveAt(model.Player, model.X, model.Y, model.Z)
+}
+
+// decodeOptionalBody decodes the JSON request body into v, an empty body
+// leaves v untouched.
+func decodeOptionalBody(r *http.Request, v interface{}) error {
+	if err := json.NewDecoder(r.Body).Decode(v); err != nil && err != io.EOF {
+		return newGameError(ErrBadRequest, "malformed request: %v", err)
+	}
+
+	return nil
+}
+
+// problem is how a refused request is reported over HTTP
+type problem struct {
+	Status int
+	Code   string
+}
+
+var problems = map[error]problem{
+	ErrBadRequest:      {http.StatusBadRequest, "bad-request"},
+	ErrUnauthorized:    {http.StatusUnauthorized, "unauthorized"},
+	ErrWrongSeat:       {http.StatusForbidden, "wrong-seat"},
+	ErrNotFound:        {http.StatusNotFound, "not-found"},
+	ErrOutOfBounds:     {http.StatusUnprocessableEntity, "out-of-bounds"},
+	ErrInvalidSettings: {http.StatusUnprocessableEntity, "invalid-settings"},
+	ErrCellOccupied:    {http.StatusConflict, "cell-occupied"},
+	ErrGameOver:        {http.StatusConflict, "game-over"},
+	ErrWrongTurn:       {http.StatusConflict, "wrong-turn"},
+	ErrNothingToUndo:   {http.StatusConflict, "nothing-to-undo"},
+	ErrNothingToRedo:   {http.StatusConflict, "nothing-to-redo"},
+	ErrSeatTaken:       {http.StatusConflict, "seat-taken"},
+	ErrTicketClosed:    {http.StatusConflict, "ticket-closed"},
+	ErrNameTaken:       {http.StatusConflict, "name-taken"},
+	ErrNoDrawOffer:     {http.StatusConflict, "no-draw-offer"},
+	ErrGameInProgress:  {http.StatusConflict, "game-in-progress"},
+	ErrSeriesOver:      {http.StatusConflict, "series-over"},
+	ErrColumnFull:      {http.StatusConflict, "column-full"},
+	ErrNotLanding:      {http.StatusConflict, "not-landing-cell"},
+	ErrInactiveBoard:   {http.StatusConflict, "inactive-board"},
 }
 
+var internalProblem = problem{http.StatusInternalServerError, "internal"}
+
+// jsonErrResponse writes the error as RFC 7807 problem details
 func jsonErrResponse(w http.ResponseWriter, err error) {
-	w.WriteHeader(http.StatusInternalServerError)
+	responseModel := newErrResponseModel(err)
 
-	_ = json.NewEncoder(w).Encode(ErrResponseModel{
-		Err: err.Error(),
-	})
+	if responseModel.Status == http.StatusUnauthorized {
+		w.Header().Set("WWW-Authenticate", "Bearer")
+	}
+
+	w.Header().Set("Content-Type", "application/problem+json")
+	w.WriteHeader(responseModel.Status)
+
+	_ = json.NewEncoder(w).Encode(responseModel)
+}
+
+// newErrResponseModel describes the error, the status and code are looked up
+// from the cause of the error.
+func newErrResponseModel(err error) ErrResponseModel {
+	p, ok := problems[errors.Cause(err)]
+	if !ok {
+		p = internalProblem
+		log.Printf("[ERROR] %v\n", err)
+	}
+
+	return ErrResponseModel{
+		Type:   "urn:tick-dock-toe:problem:" + p.Code,
+		Title:  http.StatusText(p.Status),
+		Status: p.Status,
+		Detail: err.Error(),
+		Code:   p.Code,
+		Err:    err.Error(),
+	}
 }
 
-// ErrResponseModel wraps a error in a JSON object
+// ErrResponseModel wraps a error in a JSON object following RFC 7807.  Error
+// repeats the detail for older clients.
 type ErrResponseModel struct {
-	Err string `json:"error"`
+	Type   string `json:"type"`
+	Title  string `json:"title"`
+	Status int    `json:"status"`
+	Detail string `json:"detail"`
+	Code   string `json:"code"`
+	Err    string `json:"error"`
 }
 
 func indexHandlerFunc(w http.ResponseWriter, r *http.Request) {
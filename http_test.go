@@ -0,0 +1,76 @@
+package main
+
+import (
+	"net/http"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestNewErrResponseModel(t *testing.T) {
+	tests := []struct {
+		Err    error
+		Status int
+		Code   string
+	}{
+		{ErrBadRequest, http.StatusBadRequest, "bad-request"},
+		{ErrUnauthorized, http.StatusUnauthorized, "unauthorized"},
+		{ErrWrongSeat, http.StatusForbidden, "wrong-seat"},
+		{ErrNotFound, http.StatusNotFound, "not-found"},
+		{ErrOutOfBounds, http.StatusUnprocessableEntity, "out-of-bounds"},
+		{ErrInvalidSettings, http.StatusUnprocessableEntity, "invalid-settings"},
+		{ErrCellOccupied, http.StatusConflict, "cell-occupied"},
+		{ErrGameOver, http.StatusConflict, "game-over"},
+		{ErrWrongTurn, http.StatusConflict, "wrong-turn"},
+		{ErrNothingToUndo, http.StatusConflict, "nothing-to-undo"},
+		{ErrNothingToRedo, http.StatusConflict, "nothing-to-redo"},
+		{ErrSeatTaken, http.StatusConflict, "seat-taken"},
+		{ErrTicketClosed, http.StatusConflict, "ticket-closed"},
+		{ErrNameTaken, http.StatusConflict, "name-taken"},
+		{ErrNoDrawOffer, http.StatusConflict, "no-draw-offer"},
+		{ErrGameInProgress, http.StatusConflict, "game-in-progress"},
+		{ErrSeriesOver, http.StatusConflict, "series-over"},
+		{ErrInactiveBoard, http.StatusConflict, "inactive-board"},
+		{ErrColumnFull, http.StatusConflict, "column-full"},
+		{ErrNotLanding, http.StatusConflict, "not-landing-cell"},
+	}
+
+	// every problem the server reports is covered
+	if len(problems) != len(tests) {
+		t.Errorf("unexpected problems: %d", len(problems))
+	}
+
+	for _, test := range tests {
+		for _, err := range []error{
+			test.Err,
+			newGameError(test.Err, "refused"),
+			errors.Wrap(newGameError(test.Err, "refused"), "failed to play"),
+		} {
+			model := newErrResponseModel(err)
+
+			if test.Status != model.Status || http.StatusText(test.Status) != model.Title {
+				t.Errorf("%v> unexpected status: %d %s", err, model.Status, model.Title)
+			}
+
+			if test.Code != model.Code || "urn:tick-dock-toe:problem:"+test.Code != model.Type {
+				t.Errorf("%v> unexpected code: %s %s", err, model.Code, model.Type)
+			}
+
+			if err.Error() != model.Detail || err.Error() != model.Err {
+				t.Errorf("%v> unexpected detail: %s", err, model.Detail)
+			}
+		}
+	}
+
+	err := errors.Wrap(errors.New("disk full"), "failed to save")
+
+	model := newErrResponseModel(err)
+
+	if http.StatusInternalServerError != model.Status || "internal" != model.Code {
+		t.Errorf("unexpected model: %#v", model)
+	}
+
+	if "urn:tick-dock-toe:problem:internal" != model.Type {
+		t.Error("unexpected type:", model.Type)
+	}
+}
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"log"
+	"strings"
+	"sync"
+	"time"
+)
+
+// DefaultQueueTimeout is how long a player waits in the lobby for an opponent
+// and how long a closed ticket is kept for them to collect.
+const DefaultQueueTimeout = 2 * time.Minute
+
+// Ticket states
+const (
+	TicketWaiting   = "waiting"
+	TicketMatched   = "matched"
+	TicketCancelled = "cancelled"
+	TicketExpired   = "expired"
+)
+
+// Lobby pairs the players queueing for a game in the order they arrived.
+// Each pair is given a new game, the player who waited longest plays X.
+// Tickets left waiting for longer than the Timeout are expired.
+type Lobby struct {
+	Registry *Registry
+	Timeout  time.Duration
+
+	mu      sync.Mutex
+	queue   []*lobbyTicket
+	tickets map[string]*lobbyTicket
+}
+
+// Ticket is a player's place in the lobby.  Once matched it holds the game
+// they were given, their seat and the token to play it with.
+type Ticket struct {
+	ID        string
+	Name      string
+	Status    string
+	Width     int
+	Height    int
+	WinLength int
+	GameID    string
+	Seat      int
+	Token     string
+	Created   time.Time
+	Closed    time.Time
+}
+
+type lobbyTicket struct {
+	Ticket
+
+	// done is closed once the ticket stops waiting
+	done chan struct{}
+}
+
+// NewLobby creates an empty lobby starting its games in the registry
+func NewLobby(registry *Registry, timeout time.Duration) *Lobby {
+	return &Lobby{
+		Registry: registry,
+		Timeout:  timeout,
+		tickets:  map[string]*lobbyTicket{},
+	}
+}
+
+// Queue adds a player wanting a width x height game won with winLength marks
+// in a row, zero values take the defaults.  They are matched straight away
+// when someone is already waiting for the same game.
+func (l *Lobby) Queue(width, height, winLength int) (Ticket, error) {
+	return l.QueueAs("", width, height, winLength)
+}
+
+// QueueAs adds the named player like Queue, they are never matched with
+// themselves
+func (l *Lobby) QueueAs(name string, width, height, winLength int) (Ticket, error) {
+	game, err := NewGame(width, height, winLength)
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	id, err := newToken()
+	if err != nil {
+		return Ticket{}, err
+	}
+
+	ticket := &lobbyTicket{
+		Ticket: Ticket{
+			ID:        id,
+			Name:      name,
+			Status:    TicketWaiting,
+			Width:     game.Width(),
+			Height:    game.Height(),
+			WinLength: game.WinLength,
+			Created:   now(),
+		},
+		done: make(chan struct{}),
+	}
+
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, waiting := range l.queue {
+		if waiting.Width != ticket.Width || waiting.Height != ticket.Height || waiting.WinLength != ticket.WinLength {
+			continue
+		}
+
+		if name != "" && strings.EqualFold(waiting.Name, name) {
+			continue
+		}
+
+		if err := l.match(waiting, ticket, game); err != nil {
+			return Ticket{}, err
+		}
+
+		l.tickets[id] = ticket
+
+		return ticket.Ticket, nil
+	}
+
+	l.queue = append(l.queue, ticket)
+	l.tickets[id] = ticket
+
+	return ticket.Ticket, nil
+}
+
+// Get returns the ticket with the given ID
+func (l *Lobby) Get(id string) (Ticket, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ticket, ok := l.tickets[id]
+	if !ok {
+		return Ticket{}, newGameError(ErrNotFound, "ticket not found: %s", id)
+	}
+
+	return ticket.Ticket, nil
+}
+
+// Wait returns the ticket once it stops waiting, or as it is when cancel is
+// closed first.
+func (l *Lobby) Wait(id string, cancel <-chan struct{}) (Ticket, error) {
+	l.mu.Lock()
+	ticket, ok := l.tickets[id]
+	l.mu.Unlock()
+
+	if !ok {
+		return Ticket{}, newGameError(ErrNotFound, "ticket not found: %s", id)
+	}
+
+	select {
+	case <-ticket.done:
+	case <-cancel:
+	}
+
+	return l.Get(id)
+}
+
+// Cancel takes the player out of the queue.  A ticket which has already been
+// matched can no longer be cancelled.
+func (l *Lobby) Cancel(id string) (Ticket, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	ticket, ok := l.tickets[id]
+	if !ok {
+		return Ticket{}, newGameError(ErrNotFound, "ticket not found: %s", id)
+	}
+
+	switch ticket.Status {
+	case TicketWaiting:
+		l.close(ticket, TicketCancelled)
+	case TicketMatched:
+		return Ticket{}, newGameError(ErrTicketClosed, "ticket already matched: %s", id)
+	}
+
+	return ticket.Ticket, nil
+}
+
+// Expire closes the tickets which have waited longer than the Timeout and
+// forgets those closed for as long.  It returns the number of tickets expired.
+func (l *Lobby) Expire() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	deadline := now().Add(-l.Timeout)
+	expired := 0
+
+	for id, ticket := range l.tickets {
+		switch {
+		case ticket.Status == TicketWaiting && ticket.Created.Before(deadline):
+			l.close(ticket, TicketExpired)
+			expired++
+		case ticket.Status != TicketWaiting && ticket.Closed.Before(deadline):
+			delete(l.tickets, id)
+		}
+	}
+
+	return expired
+}
+
+// ExpireEvery runs Expire on the given interval until done is closed
+func (l *Lobby) ExpireEvery(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := l.Expire(); n > 0 {
+				log.Printf("[INFO] expired %d lobby tickets\n", n)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// match starts the game for the pair, it must be called with the lock held
+func (l *Lobby) match(first, second *lobbyTicket, game *Game) error {
+	id, service, err := l.Registry.Create(game)
+	if err != nil {
+		return err
+	}
+
+	tickets := []*lobbyTicket{first, second}
+	tokens := make([]string, len(tickets))
+
+	for i := range tickets {
+		if _, tokens[i], err = service.JoinAs(i+1, tickets[i].Name); err != nil {
+			return err
+		}
+	}
+
+	l.Registry.Touch(id)
+
+	for i, ticket := range tickets {
+		ticket.GameID = id
+		ticket.Seat = i + 1
+		ticket.Token = tokens[i]
+
+		l.close(ticket, TicketMatched)
+	}
+
+	return nil
+}
+
+// close must be called with the lock held
+func (l *Lobby) close(ticket *lobbyTicket, status string) {
+	if ticket.Status == TicketWaiting {
+		for i, waiting := range l.queue {
+			if waiting == ticket {
+				l.queue = append(l.queue[:i], l.queue[i+1:]...)
+				break
+			}
+		}
+	}
+
+	ticket.Status = status
+	ticket.Closed = now()
+	close(ticket.done)
+}
@@ -0,0 +1,165 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestLobby_Queue(t *testing.T) {
+	registry := NewRegistry(time.Minute, NewMemoryStore())
+	lobby := NewLobby(registry, time.Minute)
+
+	first, err := lobby.Queue(0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if TicketWaiting != first.Status {
+		t.Error("unexpected status:", first.Status)
+	}
+
+	gomoku, err := lobby.Queue(15, 15, 5)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	second, err := lobby.Queue(3, 3, 3)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if TicketMatched != second.Status || 2 != second.Seat {
+		t.Errorf("unexpected second ticket: %#v", second)
+	}
+
+	first, err = lobby.Wait(first.ID, nil)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if TicketMatched != first.Status || 1 != first.Seat || second.GameID != first.GameID {
+		t.Errorf("unexpected first ticket: %#v", first)
+	}
+
+	service, ok := registry.Get(first.GameID)
+	if !ok {
+		t.Fatal("expected game:", first.GameID)
+	}
+
+	for _, ticket := range []Ticket{first, second} {
+		if seat, err := service.Authorize(ticket.Token); nil != err || ticket.Seat != seat {
+			t.Error("unexpected seat:", seat, err)
+		}
+	}
+
+	if gomoku, _ = lobby.Get(gomoku.ID); TicketWaiting != gomoku.Status {
+		t.Error("unexpected status:", gomoku.Status)
+	}
+
+	if _, err := lobby.Queue(20, 20, 3); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestLobby_Wait(t *testing.T) {
+	lobby := NewLobby(NewRegistry(time.Minute, NewMemoryStore()), time.Minute)
+
+	first, _ := lobby.Queue(0, 0, 0)
+
+	cancel := make(chan struct{})
+	close(cancel)
+
+	if ticket, err := lobby.Wait(first.ID, cancel); nil != err || TicketWaiting != ticket.Status {
+		t.Error("unexpected ticket:", ticket.Status, err)
+	}
+
+	matched := make(chan Ticket)
+
+	go func() {
+		ticket, _ := lobby.Wait(first.ID, nil)
+		matched <- ticket
+	}()
+
+	second, _ := lobby.Queue(0, 0, 0)
+
+	if ticket := <-matched; TicketMatched != ticket.Status || second.GameID != ticket.GameID {
+		t.Errorf("unexpected ticket: %#v", ticket)
+	}
+
+	if _, err := lobby.Wait("nope", nil); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestLobby_Cancel(t *testing.T) {
+	registry := NewRegistry(time.Minute, NewMemoryStore())
+	lobby := NewLobby(registry, time.Minute)
+
+	cancelled, _ := lobby.Queue(0, 0, 0)
+
+	if ticket, err := lobby.Cancel(cancelled.ID); nil != err || TicketCancelled != ticket.Status {
+		t.Error("unexpected ticket:", ticket.Status, err)
+	}
+
+	first, _ := lobby.Queue(0, 0, 0)
+
+	if TicketWaiting != first.Status {
+		t.Error("unexpected status:", first.Status)
+	}
+
+	lobby.Queue(0, 0, 0)
+
+	if _, err := lobby.Cancel(first.ID); ErrTicketClosed != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if n := len(registry.List()); 1 != n {
+		t.Error("unexpected games:", n)
+	}
+}
+
+func TestLobby_Expire(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	current := time.Unix(1000, 0)
+	now = func() time.Time {
+		return current
+	}
+
+	lobby := NewLobby(NewRegistry(time.Minute, NewMemoryStore()), time.Minute)
+
+	stale, _ := lobby.Queue(0, 0, 0)
+
+	current = current.Add(45 * time.Second)
+	fresh, _ := lobby.Queue(15, 15, 5)
+
+	current = current.Add(30 * time.Second)
+
+	if n := lobby.Expire(); 1 != n {
+		t.Error("unexpected expired:", n)
+	}
+
+	if ticket, _ := lobby.Get(stale.ID); TicketExpired != ticket.Status {
+		t.Error("unexpected status:", ticket.Status)
+	}
+
+	if ticket, _ := lobby.Get(fresh.ID); TicketWaiting != ticket.Status {
+		t.Error("unexpected status:", ticket.Status)
+	}
+
+	// the expired ticket is no longer matched
+	if ticket, _ := lobby.Queue(0, 0, 0); TicketWaiting != ticket.Status {
+		t.Error("unexpected status:", ticket.Status)
+	}
+
+	current = current.Add(2 * time.Minute)
+	lobby.Expire()
+
+	if _, err := lobby.Get(stale.ID); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
@@ -13,24 +13,93 @@ import (
 
 func main() {
 	bind := flag.String("bind", ":3000", "the http binding port")
+	ttl := flag.Duration("ttl", DefaultGameTTL, "how long finished or idle games are kept")
+	queueTimeout := flag.Duration("queue-timeout", DefaultQueueTimeout, "how long players wait in the lobby for an opponent")
+	abandonAfter := flag.Duration("abandon-after", DefaultAbandonAfter, "how long a player may be disconnected or keep their opponent waiting before forfeiting, zero never forfeits")
+	dataDir := flag.String("data-dir", "", "where games are saved to survive restarts, kept in memory when empty")
 	flag.Parse()
 
-	game := &Game{}
-	game.Reset()
+	var store Store = NewMemoryStore()
+
+	if *dataDir != "" {
+		fileStore, err := NewFileStore(*dataDir)
+		if err != nil {
+			log.Fatalln(err)
+		}
+
+		store = fileStore
+	}
+
+	ratings := NewRatings(store)
+
+	players, err := ratings.Load()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if players > 0 {
+		log.Printf("[INFO] loaded %d players\n", players)
+	}
+
+	registry := NewRegistry(*ttl, store)
+	registry.Ratings = ratings
+	registry.AbandonAfter = *abandonAfter
+
+	tournaments := NewTournaments(registry, store)
+	registry.Tournaments = tournaments
+
+	loaded, err := registry.Load()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if loaded > 0 {
+		log.Printf("[INFO] loaded %d games\n", loaded)
+	}
+
+	running, err := tournaments.Load()
+	if err != nil {
+		log.Fatalln(err)
+	}
+
+	if running > 0 {
+		log.Printf("[INFO] loaded %d tournaments\n", running)
+	}
+
+	done := make(chan struct{})
+	defer close(done)
+
+	if *ttl > 0 {
+		go registry.EvictEvery(*ttl/2, done)
+	}
+
+	go registry.CheckClocksEvery(ClockInterval, done)
+
+	lobby := NewLobby(registry, *queueTimeout)
+
+	if *queueTimeout > 0 {
+		go lobby.ExpireEvery(*queueTimeout/2, done)
+	}
 
 	mux := http.NewServeMux()
 
 	mw := newLoggingMiddlewareHandlerFunc
 
 	mux.Handle("/", mw(indexHandlerFunc))
-	mux.Handle("/state", mw(newStateHandlerFunc(game)))
-	mux.Handle("/move", mw(newMakeMoveHandlerFunc(game)))
-	mux.Handle("/new", mw(newNewGameHandlerFunc(game)))
+	mux.Handle("/games", mw(newGamesHandlerFunc(registry)))
+	mux.Handle("/games/", mw(newGamesHandlerFunc(registry)))
+	mux.Handle("/lobby/", mw(newLobbyHandlerFunc(lobby)))
+	mux.Handle("/players", mw(newPlayersHandlerFunc(ratings)))
+	mux.Handle("/players/", mw(newPlayersHandlerFunc(ratings)))
+	mux.Handle("/leaderboard", mw(newLeaderboardHandlerFunc(ratings)))
+	mux.Handle("/rules", mw(rulesHandlerFunc))
+	mux.Handle("/tournaments", mw(newTournamentsHandlerFunc(tournaments)))
+	mux.Handle("/tournaments/", mw(newTournamentsHandlerFunc(tournaments)))
 
+	// there is no WriteTimeout as the event streams stay open indefinitely
 	server := http.Server{
-		ReadTimeout:  5 * time.Second,
-		WriteTimeout: 10 * time.Second,
-		Handler:      mux,
+		ReadTimeout: 5 * time.Second,
+		Handler:     mux,
 	}
 
 	listener, err := net.Listen("tcp", *bind)
@@ -56,7 +125,7 @@ func main() {
 }
 
 func interrupt() chan os.Signal {
-	signalch := make(chan os.Signal)
+	signalch := make(chan os.Signal, 1)
 	signal.Notify(signalch, syscall.SIGINT, syscall.SIGTERM)
 	return signalch
 }
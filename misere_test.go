@@ -0,0 +1,103 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+)
+
+func TestGame_Misere(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	game.SetMisere(true)
+
+	// X completes the top row and so loses
+	for _, move := range [][2]int{{0, 0}, {1, 1}, {1, 0}, {2, 2}, {2, 0}} {
+		if err := game.MakeMove(move[0], move[1]); err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+	}
+
+	if StatusEnd != game.Status || 2 != game.Winner || nil == game.WinningLine || LineColumn != game.WinningLine.Kind {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if replayed := ReplayGame(game.Log); !replayed.Misere || 2 != replayed.Winner {
+		t.Errorf("unexpected replay: %#v", replayed)
+	}
+
+	// the rule carries over to the next game
+	_ = game.Resize(4, 4, 3)
+
+	if !game.Misere {
+		t.Error("unexpected misere:", game.Misere)
+	}
+
+	game.SetMisere(false)
+
+	for _, move := range [][2]int{{0, 0}, {1, 1}, {1, 0}, {2, 2}, {2, 0}} {
+		_ = game.MakeMove(move[0], move[1])
+	}
+
+	if 1 != game.Winner {
+		t.Error("unexpected winner:", game.Winner)
+	}
+}
+
+func TestBotState_Misere(t *testing.T) {
+	game, _ := NewGame(3, 3, 3)
+	game.SetMisere(true)
+
+	// O to move with two in the middle column, only x 1, y 0 completes it
+	for _, move := range [][2]int{{0, 0}, {1, 1}, {2, 2}, {1, 2}, {2, 1}} {
+		_ = game.MakeMove(move[0], move[1])
+	}
+
+	for _, level := range []ai.Level{ai.Greedy, ai.Perfect} {
+		for i := 0; i < 10; i++ {
+			move, err := ai.NewPlayer(level).Move(&botState{game: game.Clone()})
+			if err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+
+			if (ai.Move{X: 1, Y: 0}) == move {
+				t.Fatalf("%s> completed a line", level)
+			}
+		}
+	}
+
+	// lines count against whoever holds them
+	normal := game.Clone()
+	normal.Misere = false
+
+	if score := (&botState{game: game}).Evaluate(2); 0 == score || -(&botState{game: normal}).Evaluate(2) != score {
+		t.Error("unexpected score:", score)
+	}
+}
+
+func TestGameService_Bot_MisereNeverLoses(t *testing.T) {
+	random := ai.NewPlayer(ai.Random)
+
+	for i := 0; i < 20; i++ {
+		game, _ := NewGame(3, 3, 3)
+		game.SetMisere(true)
+
+		service := NewGameService(game)
+
+		snapshot, _ := service.SetBot(ai.NewPlayer(ai.Perfect), 1+i%2)
+
+		for snapshot.Status == StatusAlive {
+			move, err := random.Move(&botState{game: snapshot.Game.Clone()})
+			if err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+
+			if snapshot, err = service.MakeMove(move.X, move.Y); err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+		}
+
+		if winner := snapshot.Winner; 0 != winner && snapshot.BotPlayer != winner {
+			t.Fatalf("%d> bot lost: %#v", i, snapshot.Board)
+		}
+	}
+}
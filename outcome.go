@@ -0,0 +1,137 @@
+package main
+
+import "time"
+
+// Resign ends the game as lost for the player, zero resigns for the player
+// to move.
+func (g *Game) Resign(player int) error {
+	if player == 0 {
+		player = g.Player
+	}
+
+	if err := g.checkDecision(player); err != nil {
+		return err
+	}
+
+	g.record(Action{Type: ActionResign, Player: player})
+
+	return nil
+}
+
+// OfferDraw offers the opponent a draw, zero offers for the player to move.
+// The offer stands until the opponent accepts or declines it, or makes a
+// move, DrawOffer holds who made it until then.  Offering a draw the opponent
+// has already offered accepts it.
+func (g *Game) OfferDraw(player int) error {
+	if player == 0 {
+		player = g.Player
+	}
+
+	if err := g.checkDecision(player); err != nil {
+		return err
+	}
+
+	switch g.DrawOffer {
+	case player:
+		return nil
+	case 3 - player:
+		g.record(Action{Type: ActionAcceptDraw, Player: player})
+	default:
+		g.record(Action{Type: ActionOfferDraw, Player: player})
+	}
+
+	return nil
+}
+
+// AcceptDraw ends the game as drawn by agreement, zero accepts on behalf of
+// whoever the draw was offered to.
+func (g *Game) AcceptDraw(player int) error {
+	return g.answerDraw(ActionAcceptDraw, player)
+}
+
+// DeclineDraw turns the draw offer down, zero declines on behalf of whoever
+// the draw was offered to.
+func (g *Game) DeclineDraw(player int) error {
+	return g.answerDraw(ActionDeclineDraw, player)
+}
+
+// Abandon ends the game as lost for a player who has left it
+func (g *Game) Abandon(player int) error {
+	if err := g.checkDecision(player); err != nil {
+		return err
+	}
+
+	g.record(Action{Type: ActionAbandon, Player: player})
+
+	return nil
+}
+
+// IdleSince is when the player to move was given the move, by the last move
+// made, taken back or replayed or by the reset.  Refused moves and draw offers
+// don't count so they can't be used to stall.
+func (g *Game) IdleSince() time.Time {
+	for i := len(g.Log) - 1; i >= 0; i-- {
+		switch action := g.Log[i]; action.Type {
+		case ActionReset, ActionMove, ActionUndo, ActionRedo:
+			return action.Time
+		}
+	}
+
+	return time.Time{}
+}
+
+func (g *Game) answerDraw(actionType string, player int) error {
+	if g.DrawOffer == 0 {
+		return newGameError(ErrNoDrawOffer, "no draw offered")
+	}
+
+	if player == 0 {
+		player = 3 - g.DrawOffer
+	}
+
+	if err := g.checkDecision(player); err != nil {
+		return err
+	}
+
+	if player == g.DrawOffer {
+		return newGameError(ErrNoDrawOffer, "player %d offered the draw", player)
+	}
+
+	g.record(Action{Type: actionType, Player: player})
+
+	return nil
+}
+
+// checkDecision determines if player may resign, abandon or settle on a draw
+func (g *Game) checkDecision(player int) error {
+	if g.isOver() {
+		return ErrGameOver
+	}
+
+	if player != 1 && player != 2 {
+		return newGameError(ErrInvalidSettings, "invalid player: %d", player)
+	}
+
+	return nil
+}
+
+// isOver determines if the game has ended one way or another
+func (g *Game) isOver() bool {
+	switch g.Status {
+	case StatusDraw, StatusEnd:
+		return true
+	}
+
+	return g.isDecided()
+}
+
+// isDecided determines if the game ended other than on the board, which
+// taking back a move can't change
+func (g *Game) isDecided() bool {
+	switch g.Status {
+	case StatusTimeout, StatusResigned, StatusAgreedDraw, StatusAbandoned:
+		return true
+	}
+
+	return false
+}
@@ -0,0 +1,155 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestResign(t *testing.T) {
+	game := testNewGame()
+
+	_ = game.MakeMove(0, 0)
+
+	if err := game.Resign(0); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if StatusResigned != game.Status || 1 != game.Winner {
+		t.Error("unexpected result:", game.Status, game.Winner)
+	}
+
+	if err := game.MakeMove(1, 1); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.Undo(); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.Resign(1); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if replayed := ReplayGame(game.Log); StatusResigned != replayed.Status || 1 != replayed.Winner {
+		t.Error("unexpected replayed result:", replayed.Status, replayed.Winner)
+	}
+
+	game.Reset()
+
+	if err := game.Resign(3); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestDrawOffer(t *testing.T) {
+	game := testNewGame()
+
+	if err := game.AcceptDraw(0); ErrNoDrawOffer != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.OfferDraw(1); err != nil || 1 != game.DrawOffer {
+		t.Error("unexpected offer:", game.DrawOffer, err)
+	}
+
+	if err := game.AcceptDraw(1); ErrNoDrawOffer != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.DeclineDraw(2); err != nil || 0 != game.DrawOffer {
+		t.Error("unexpected offer:", game.DrawOffer, err)
+	}
+
+	// moving instead of answering declines the offer
+	_ = game.OfferDraw(1)
+	_ = game.MakeMove(0, 0)
+	_ = game.MakeMove(1, 1)
+
+	if 0 != game.DrawOffer {
+		t.Error("unexpected offer:", game.DrawOffer)
+	}
+
+	// but the offer stands while the player who made it moves
+	_ = game.OfferDraw(1)
+	_ = game.MakeMove(2, 2)
+
+	if 1 != game.DrawOffer {
+		t.Error("unexpected offer:", game.DrawOffer)
+	}
+
+	// offering the draw back accepts it
+	if err := game.OfferDraw(2); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if StatusAgreedDraw != game.Status || 0 != game.Winner || 0 != game.DrawOffer {
+		t.Error("unexpected result:", game.Status, game.Winner, game.DrawOffer)
+	}
+
+	if err := game.DeclineDraw(0); ErrNoDrawOffer != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestGameService_CheckAbandoned(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	current := time.Unix(1000, 0)
+	now = func() time.Time {
+		return current
+	}
+
+	service := NewGameService(testNewGame())
+	service.Join(1)
+	service.Join(2)
+
+	// nobody forfeits before the first move
+	current = current.Add(time.Hour)
+
+	if service.CheckAbandoned(time.Minute) {
+		t.Error("unexpected abandon")
+	}
+
+	service.MakeMove(0, 0)
+
+	current = current.Add(59 * time.Second)
+
+	if service.CheckAbandoned(time.Minute) {
+		t.Error("unexpected abandon")
+	}
+
+	// a connected player still forfeits by not moving, refused moves don't
+	// count as moving
+	service.Connect(2)
+	service.MakeMoveAt(2, 0, 0, 0)
+	current = current.Add(time.Second)
+
+	if !service.CheckAbandoned(time.Minute) {
+		t.Fatal("expected abandon")
+	}
+
+	if game := service.Snapshot(); StatusAbandoned != game.Status || 1 != game.Winner {
+		t.Error("unexpected result:", game.Status, game.Winner)
+	}
+
+	service.Reset()
+	service.MakeMove(0, 0)
+
+	// X left while it is O's move
+	service.Connect(1)()
+	current = current.Add(30 * time.Second)
+	service.MakeMove(1, 1)
+	current = current.Add(30 * time.Second)
+
+	if !service.CheckAbandoned(time.Minute) {
+		t.Fatal("expected abandon")
+	}
+
+	if game := service.Snapshot(); 2 != game.Winner {
+		t.Error("unexpected winner:", game.Winner)
+	}
+}
@@ -0,0 +1,252 @@
+package main
+
+import (
+	"sort"
+
+	"github.com/pkg/errors"
+)
+
+// QubicSize is how many cells the qubic cube is across in every direction
+const QubicSize = 4
+
+// Kinds of lines only found in three dimensions
+const (
+	LinePillar        = "pillar"
+	LineSpaceDiagonal = "space-diagonal"
+)
+
+// QubicRules play VariantQubic on Layers of the cube, indexed as
+// Layers[z][x][y] in place of the Board, any of its lines wins
+type QubicRules struct{}
+
+// Name is VariantQubic
+func (QubicRules) Name() string {
+	return VariantQubic
+}
+
+// Setup only allows the qubic cube
+func (QubicRules) Setup(width, height, winLength int) (Action, error) {
+	return fixedSetup(Action{Type: ActionReset, Variant: VariantQubic, Width: QubicSize, Height: QubicSize, Depth: QubicSize, WinLength: QubicSize}, width, height, winLength)
+}
+
+// Start lays out empty Layers, the Board is left unset
+func (QubicRules) Start(game *Game, reset Action) {
+	game.Layers = make([][][]int, reset.Depth)
+
+	for z := range game.Layers {
+		game.Layers[z] = newBoard(reset.Width, reset.Height)
+	}
+}
+
+// Moves lists the empty cells of every layer
+func (QubicRules) Moves(game *Game) []Cell {
+	var cells []Cell
+
+	for z, layer := range game.Layers {
+		for x := range layer {
+			for y := range layer[x] {
+				if layer[x][y] == 0 {
+					cells = append(cells, Cell{X: x, Y: y, Z: z})
+				}
+			}
+		}
+	}
+
+	return cells
+}
+
+// CheckMove determines if the cell is an empty one in the cube
+func (QubicRules) CheckMove(game *Game, cell Cell) error {
+	if cell.Z < 0 || cell.Z >= len(game.Layers) {
+		return errors.Wrap(newGameError(ErrOutOfBounds, "invalid z index: %d", cell.Z), "invalid move")
+	}
+
+	if err := isValidMove(game.Layers[cell.Z], cell.X, cell.Y); err != nil {
+		return errors.Wrap(err, "invalid move")
+	}
+
+	return nil
+}
+
+// Apply marks the cell for the player who moved
+func (QubicRules) Apply(game *Game, move Move) {
+	game.Layers[move.Z][move.X][move.Y] = move.Player
+}
+
+// Revert empties the cell again
+func (QubicRules) Revert(game *Game, move Move) {
+	game.Layers[move.Z][move.X][move.Y] = 0
+}
+
+// Outcome checks every line of the cube
+func (QubicRules) Outcome(game *Game, player int) (Line, bool) {
+	return isCubeWin(game.Layers, player)
+}
+
+// Terminal determines if every layer is full
+func (QubicRules) Terminal(game *Game) bool {
+	for _, layer := range game.Layers {
+		if !isBoardFull(layer) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// OrderMoves searches the cells on the most lines first
+func (QubicRules) OrderMoves(game *Game, moves []Cell) []Cell {
+	sort.SliceStable(moves, func(i, j int) bool {
+		return qubicCellLines[moves[i]] > qubicCellLines[moves[j]]
+	})
+
+	return moves
+}
+
+// Evaluate scores every line of the cube
+func (QubicRules) Evaluate(game *Game, player int) int {
+	score := 0
+
+	for _, line := range qubicLines {
+		score += lineWeight(countLine(game.Layers, line, player))
+	}
+
+	return score
+}
+
+// SearchDepth is 3 moves, the cube is too large to be searched to the end
+func (QubicRules) SearchDepth(game *Game) int {
+	return 3
+}
+
+// qubicLines are the 76 lines of the qubic cube
+var qubicLines = cubeLines(QubicSize, QubicSize, QubicSize, QubicSize)
+
+// qubicCellLines counts the lines through each cell of the qubic cube, the
+// corners and the centre are on 7 while every other cell is on 4
+var qubicCellLines = countCellLines(qubicLines)
+
+// cubeLines enumerates every run of winLength cells in a straight line on a
+// width x height x depth board, in each of the 13 directions a line can run
+func cubeLines(width, height, depth, winLength int) []Line {
+	var lines []Line
+
+	for _, dir := range cubeDirections() {
+		for x := 0; x < width; x++ {
+			for y := 0; y < height; y++ {
+				for z := 0; z < depth; z++ {
+					ex, ey, ez := x+(winLength-1)*dir[0], y+(winLength-1)*dir[1], z+(winLength-1)*dir[2]
+
+					if ex < 0 || ex >= width || ey < 0 || ey >= height || ez < 0 || ez >= depth {
+						continue
+					}
+
+					line := Line{Kind: cubeLineKind(dir)}
+
+					for i := 0; i < winLength; i++ {
+						line.Cells = append(line.Cells, Cell{X: x + i*dir[0], Y: y + i*dir[1], Z: z + i*dir[2]})
+					}
+
+					lines = append(lines, line)
+				}
+			}
+		}
+	}
+
+	return lines
+}
+
+// cubeDirections are the dx, dy, dz steps lines can take, only one of each
+// pair of opposite directions is included
+func cubeDirections() [][3]int {
+	var dirs [][3]int
+
+	for dx := -1; dx <= 1; dx++ {
+		for dy := -1; dy <= 1; dy++ {
+			for dz := -1; dz <= 1; dz++ {
+				dir := [3]int{dx, dy, dz}
+
+				for _, d := range dir {
+					if d < 0 {
+						break
+					}
+
+					if d > 0 {
+						dirs = append(dirs, dir)
+						break
+					}
+				}
+			}
+		}
+	}
+
+	return dirs
+}
+
+// cubeLineKind names the lines running in the direction, lines along a
+// single axis or a diagonal of a plane are named as on a flat board
+func cubeLineKind(dir [3]int) string {
+	axes, product := 0, 1
+
+	for _, d := range dir {
+		if d != 0 {
+			axes++
+			product *= d
+		}
+	}
+
+	switch {
+	case axes == 3:
+		return LineSpaceDiagonal
+	case axes == 2 && product > 0:
+		return LineDiagonal
+	case axes == 2:
+		return LineAntiDiagonal
+	case dir[0] != 0:
+		return LineColumn
+	case dir[1] != 0:
+		return LineRow
+	}
+
+	return LinePillar
+}
+
+func countCellLines(lines []Line) map[Cell]int {
+	counts := map[Cell]int{}
+
+	for _, line := range lines {
+		for _, cell := range line.Cells {
+			counts[cell]++
+		}
+	}
+
+	return counts
+}
+
+// isCubeWin determines if the player holds every cell of any line of the
+// qubic cube and returns the line
+func isCubeWin(layers [][][]int, player int) (Line, bool) {
+	for _, line := range qubicLines {
+		mine, _ := countLine(layers, line, player)
+
+		if mine == len(line.Cells) {
+			return Line{Kind: line.Kind, Cells: append([]Cell(nil), line.Cells...)}, true
+		}
+	}
+
+	return Line{}, false
+}
+
+// countLine counts the marks of the player and their opponent on the line
+func countLine(layers [][][]int, line Line, player int) (mine, theirs int) {
+	for _, cell := range line.Cells {
+		switch val := layers[cell.Z][cell.X][cell.Y]; {
+		case val == player:
+			mine++
+		case val != 0:
+			theirs++
+		}
+	}
+
+	return mine, theirs
+}
@@ -0,0 +1,149 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+	"github.com/pkg/errors"
+)
+
+func TestCubeLines(t *testing.T) {
+	if 76 != len(qubicLines) {
+		t.Fatal("unexpected lines:", len(qubicLines))
+	}
+
+	kinds := map[string]int{}
+
+	for _, line := range qubicLines {
+		kinds[line.Kind]++
+	}
+
+	expected := map[string]int{
+		LineColumn:        16,
+		LineRow:           16,
+		LinePillar:        16,
+		LineDiagonal:      12,
+		LineAntiDiagonal:  12,
+		LineSpaceDiagonal: 4,
+	}
+
+	if !reflect.DeepEqual(expected, kinds) {
+		t.Errorf("unexpected kinds: %#v", kinds)
+	}
+
+	for cell, lines := range map[Cell]int{{X: 0, Y: 0, Z: 0}: 7, {X: 1, Y: 2, Z: 1}: 7, {X: 1, Y: 0, Z: 0}: 4} {
+		if lines != qubicCellLines[cell] {
+			t.Errorf("unexpected lines through %#v: %d", cell, qubicCellLines[cell])
+		}
+	}
+
+	// a flat board has the lines of the 2D win checks
+	if lines := cubeLines(3, 3, 1, 3); 8 != len(lines) {
+		t.Error("unexpected lines:", len(lines))
+	}
+}
+
+func TestGame_Qubic(t *testing.T) {
+	game, err := NewVariantGame(VariantQubic, 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if QubicSize != game.Width() || QubicSize != game.Height() || QubicSize != game.Depth() || nil != game.Board {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if err := game.MakeMoveAt(1, 0, 0, 4); ErrOutOfBounds != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	// X takes the space diagonal while O stacks up a pillar
+	for i := 0; i < 3; i++ {
+		_ = game.MakeMoveAt(1, i, i, i)
+		_ = game.MakeMoveAt(2, 0, 3, i)
+	}
+
+	if err := game.MakeMoveAt(2, 0, 3, 3); ErrWrongTurn != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	clone := game.Clone()
+
+	if err := game.MakeMoveAt(1, 3, 3, 3); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if 0 != clone.Layers[3][3][3] {
+		t.Error("unexpected clone:", clone.Layers)
+	}
+
+	expected := Line{Kind: LineSpaceDiagonal, Cells: []Cell{{X: 0, Y: 0, Z: 0}, {X: 1, Y: 1, Z: 1}, {X: 2, Y: 2, Z: 2}, {X: 3, Y: 3, Z: 3}}}
+
+	if StatusEnd != game.Status || 1 != game.Winner || nil == game.WinningLine || !reflect.DeepEqual(expected, *game.WinningLine) {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	_ = game.Undo()
+
+	if 0 != game.Layers[3][3][3] || StatusAlive != game.Status {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game.Layers, replayed.Layers) {
+		t.Error("unexpected replay:", replayed.Layers)
+	}
+}
+
+func TestBotState_Qubic(t *testing.T) {
+	game, _ := NewVariantGame(VariantQubic, 0, 0, 0)
+
+	// X threatens the bottom row of the lowest layer
+	for _, move := range [][3]int{{0, 0, 0}, {1, 1, 1}, {1, 0, 0}, {2, 2, 2}, {2, 0, 0}} {
+		_ = game.MakeMoveAt(game.Player, move[0], move[1], move[2])
+	}
+
+	moves := (&botState{game: game}).Moves()
+
+	if 59 != len(moves) || 7 != qubicCellLines[Cell(moves[0])] {
+		t.Errorf("unexpected moves: %#v", moves)
+	}
+
+	bot := ai.NewPlayer(ai.Perfect)
+	bot.MaxDepth = botDepth(game)
+
+	if move, err := bot.Move(&botState{game: game}); err != nil || (ai.Move{X: 3, Y: 0, Z: 0}) != move {
+		t.Error("unexpected move:", move, err)
+	}
+}
+
+func TestGameService_Bot_Qubic(t *testing.T) {
+	game, _ := NewVariantGame(VariantQubic, 0, 0, 0)
+	service := NewGameService(game)
+
+	started := time.Now()
+
+	snapshot, err := service.SetBot(ai.NewPlayer(ai.Perfect), 1)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	// reply on a side of the cube, wherever the bot opened
+	z := 3
+	if 0 != snapshot.Layers[z][1][2] {
+		z = 0
+	}
+
+	if snapshot, err = service.MakeMoveAt(0, 1, 2, z); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if numMoves := len(snapshot.History); 3 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Error("unexpected elapsed:", elapsed)
+	}
+}
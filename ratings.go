@@ -0,0 +1,255 @@
+package main
+
+import (
+	"crypto/subtle"
+	"math"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Elo settings, new players start on the DefaultRating
+const (
+	DefaultRating = 1200
+	RatingK       = 32
+	MaxNameLength = 32
+)
+
+// Results of a rated game
+const (
+	ResultWin  = "win"
+	ResultLoss = "loss"
+	ResultDraw = "draw"
+)
+
+// GameResult is how a game between two named players ended, a zero Winner is
+// a draw.
+type GameResult struct {
+	Names  [3]string
+	Winner int
+}
+
+// Ratings keeps the player accounts and updates their Elo ratings as their
+// games end.  Every change is saved to the Store.
+type Ratings struct {
+	Store Store
+
+	mu      sync.Mutex
+	players map[string]*PlayerRecord
+}
+
+// NewRatings creates an empty set of players saved to the store
+func NewRatings(store Store) *Ratings {
+	return &Ratings{
+		Store:   store,
+		players: map[string]*PlayerRecord{},
+	}
+}
+
+// Load adds every player in the store and returns how many there were
+func (r *Ratings) Load() (int, error) {
+	records, err := r.Store.ListPlayers()
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for i := range records {
+		r.players[strings.ToLower(records[i].Name)] = &records[i]
+	}
+
+	return len(records), nil
+}
+
+// Register creates a player, returning it with the key needed to play as
+// them.  Names are unique regardless of case.
+func (r *Ratings) Register(name string) (PlayerRecord, error) {
+	if !isValidPlayerName(name) {
+		return PlayerRecord{}, newGameError(ErrInvalidSettings, "invalid name: %s", name)
+	}
+
+	key, err := newToken()
+	if err != nil {
+		return PlayerRecord{}, errors.Wrap(err, "failed to create key")
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.players[strings.ToLower(name)]; ok {
+		return PlayerRecord{}, newGameError(ErrNameTaken, "name taken: %s", name)
+	}
+
+	record := &PlayerRecord{
+		Name:    name,
+		Key:     key,
+		Rating:  DefaultRating,
+		Created: now(),
+	}
+
+	err = r.Store.CreatePlayer(*record)
+	if errors.Cause(err) == ErrPlayerExists {
+		return PlayerRecord{}, newGameError(ErrNameTaken, "name taken: %s", name)
+	}
+
+	if err != nil {
+		return PlayerRecord{}, err
+	}
+
+	r.players[strings.ToLower(name)] = record
+
+	return record.copy(), nil
+}
+
+// Authorize returns the name of the player the key belongs to, as it was
+// registered
+func (r *Ratings) Authorize(name, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.players[strings.ToLower(name)]
+	if !ok {
+		return "", newGameError(ErrNotFound, "player not found: %s", name)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(record.Key), []byte(key)) != 1 {
+		return "", newGameError(ErrUnauthorized, "invalid key for player: %s", name)
+	}
+
+	return record.Name, nil
+}
+
+// Player returns the player with the given name
+func (r *Ratings) Player(name string) (PlayerRecord, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	record, ok := r.players[strings.ToLower(name)]
+	if !ok {
+		return PlayerRecord{}, newGameError(ErrNotFound, "player not found: %s", name)
+	}
+
+	return record.copy(), nil
+}
+
+// Leaderboard returns every player, highest rated first
+func (r *Ratings) Leaderboard() []PlayerRecord {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	records := make([]PlayerRecord, 0, len(r.players))
+	for _, record := range r.players {
+		records = append(records, record.copy())
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if records[i].Rating != records[j].Rating {
+			return records[i].Rating > records[j].Rating
+		}
+
+		return strings.ToLower(records[i].Name) < strings.ToLower(records[j].Name)
+	})
+
+	return records
+}
+
+// Rate updates the ratings of both players once their game has ended.  Both
+// players are changed by the same amount, so no rating is created or lost.
+func (r *Ratings) Rate(gameID string, result GameResult) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var players [3]*PlayerRecord
+
+	for seat := 1; seat <= 2; seat++ {
+		record, ok := r.players[strings.ToLower(result.Names[seat])]
+		if !ok {
+			return newGameError(ErrNotFound, "player not found: %s", result.Names[seat])
+		}
+
+		players[seat] = record
+	}
+
+	if players[1] == players[2] {
+		return nil
+	}
+
+	score := 0.5
+
+	switch result.Winner {
+	case 1:
+		score = 1
+	case 2:
+		score = 0
+	}
+
+	change := int(math.Floor(RatingK*(score-expectedScore(players[1].Rating, players[2].Rating)) + 0.5))
+	changes := [3]int{0, change, -change}
+	ratedAt := now()
+
+	for seat := 1; seat <= 2; seat++ {
+		player, opponent := players[seat], players[3-seat]
+
+		outcome := ResultDraw
+
+		switch result.Winner {
+		case 0:
+			player.Draws++
+		case seat:
+			outcome = ResultWin
+			player.Wins++
+		default:
+			outcome = ResultLoss
+			player.Losses++
+		}
+
+		player.Rating += changes[seat]
+		player.History = append(player.History, RatingRecord{
+			GameID:   gameID,
+			Opponent: opponent.Name,
+			Result:   outcome,
+			Rating:   player.Rating,
+			Change:   changes[seat],
+			Time:     ratedAt,
+		})
+
+		if err := r.Store.SavePlayer(*player); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// copy must be called with the lock held
+func (p *PlayerRecord) copy() PlayerRecord {
+	record := *p
+	record.History = append([]RatingRecord(nil), p.History...)
+
+	return record
+}
+
+// expectedScore is the chance of a player rated rating beating one rated
+// opponent, counting a draw as half a win
+func expectedScore(rating, opponent int) float64 {
+	return 1 / (1 + math.Pow(10, float64(opponent-rating)/400))
+}
+
+// isValidPlayerName allows letters, digits, dashes, dots and underscores
+func isValidPlayerName(name string) bool {
+	if name == "" || len(name) > MaxNameLength {
+		return false
+	}
+
+	for _, c := range name {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'z') && (c < 'A' || c > 'Z') && c != '-' && c != '_' && c != '.' {
+			return false
+		}
+	}
+
+	return name != "." && name != ".."
+}
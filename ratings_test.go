@@ -0,0 +1,147 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestRatings_Register(t *testing.T) {
+	ratings := NewRatings(NewMemoryStore())
+
+	alice, err := ratings.Register("Alice")
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if DefaultRating != alice.Rating || "" == alice.Key {
+		t.Errorf("unexpected player: %#v", alice)
+	}
+
+	if _, err := ratings.Register("alice"); ErrNameTaken != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	for _, name := range []string{"", "..", "a/b", "a b", "abcdefghijklmnopqrstuvwxyz0123456"} {
+		if _, err := ratings.Register(name); ErrInvalidSettings != errors.Cause(err) {
+			t.Errorf("%q> unexpected err: %v", name, err)
+		}
+	}
+
+	if name, err := ratings.Authorize("ALICE", alice.Key); nil != err || "Alice" != name {
+		t.Error("unexpected name:", name, err)
+	}
+
+	if _, err := ratings.Authorize("alice", "nope"); ErrUnauthorized != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := ratings.Authorize("bob", alice.Key); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestRatings_Rate(t *testing.T) {
+	store := NewMemoryStore()
+	ratings := NewRatings(store)
+
+	ratings.Register("alice")
+	ratings.Register("bob")
+	ratings.Register("carol")
+
+	if err := ratings.Rate("g1", GameResult{Names: [3]string{"", "alice", "bob"}, Winner: 1}); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	alice, _ := ratings.Player("alice")
+	bob, _ := ratings.Player("bob")
+
+	if 1216 != alice.Rating || 1 != alice.Wins || 1 != len(alice.History) {
+		t.Errorf("unexpected alice: %#v", alice)
+	}
+
+	if 1184 != bob.Rating || 1 != bob.Losses || 1 != len(bob.History) {
+		t.Errorf("unexpected bob: %#v", bob)
+	}
+
+	record := bob.History[0]
+	record.Time = time.Time{}
+
+	if expected := (RatingRecord{GameID: "g1", Opponent: "alice", Result: ResultLoss, Rating: 1184, Change: -16}); expected != record {
+		t.Errorf("unexpected history: %#v", record)
+	}
+
+	// drawing with a lower rated player costs the favourite
+	if err := ratings.Rate("g2", GameResult{Names: [3]string{"", "bob", "alice"}}); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if alice, _ = ratings.Player("alice"); 1215 != alice.Rating || 1 != alice.Draws {
+		t.Errorf("unexpected alice: %#v", alice)
+	}
+
+	if err := ratings.Rate("g3", GameResult{Names: [3]string{"", "alice", "dave"}, Winner: 1}); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	leaderboard := ratings.Leaderboard()
+	if 3 != len(leaderboard) || "alice" != leaderboard[0].Name || "carol" != leaderboard[1].Name || "bob" != leaderboard[2].Name {
+		t.Errorf("unexpected leaderboard: %#v", leaderboard)
+	}
+
+	loaded := NewRatings(store)
+
+	if n, err := loaded.Load(); nil != err || 3 != n {
+		t.Error("unexpected loaded:", n, err)
+	}
+
+	if alice, _ = loaded.Player("alice"); 1215 != alice.Rating || 2 != len(alice.History) {
+		t.Errorf("unexpected alice: %#v", alice)
+	}
+}
+
+func TestRegistry_Touch_Rates(t *testing.T) {
+	store := NewMemoryStore()
+	ratings := NewRatings(store)
+
+	registry := NewRegistry(time.Minute, store)
+	registry.Ratings = ratings
+
+	ratings.Register("alice")
+	ratings.Register("bob")
+
+	id, service, _ := registry.Create(testNewGame())
+	service.JoinAs(1, "alice")
+	service.JoinAs(2, "bob")
+
+	for _, move := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}} {
+		service.MakeMove(move[0], move[1])
+	}
+
+	registry.Touch(id)
+	registry.Touch(id)
+
+	if alice, _ := ratings.Player("alice"); 1216 != alice.Rating || 1 != alice.Wins {
+		t.Errorf("unexpected alice: %#v", alice)
+	}
+
+	if record, _ := store.Load(id); !record.Rated {
+		t.Error("expected rated record")
+	}
+
+	// the result stands, it can't be taken back or played over
+	if _, err := service.Undo(0); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := service.Reset(); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	registry.Touch(id)
+
+	if bob, _ := ratings.Player("bob"); 0 != bob.Wins || 1 != bob.Losses {
+		t.Errorf("unexpected bob: %#v", bob)
+	}
+}
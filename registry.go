@@ -0,0 +1,372 @@
+package main
+
+import (
+	"crypto/rand"
+	"encoding/hex"
+	"log"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// DefaultGameTTL is how long a game may go without activity before it is
+// evicted from the registry.
+const DefaultGameTTL = time.Hour
+
+// DefaultAbandonAfter is how long a player may be disconnected, or keep their
+// opponent waiting, before they forfeit the game
+const DefaultAbandonAfter = 5 * time.Minute
+
+// ClockInterval is how often the clocks of games nobody is moving in are
+// checked, moves are checked against the clock as they are made
+const ClockInterval = 250 * time.Millisecond
+
+// Registry tracks every game being played on the server by a unique ID and
+// evicts the games which have finished or gone idle.  Games are kept in the
+// Store as well so they can be loaded again after a restart.  When Ratings is
+// set the games between named players are rated as they end, and reported to
+// the Tournaments when they are set.  Players who leave or stop moving for
+// AbandonAfter forfeit, zero never forfeits them.
+type Registry struct {
+	TTL          time.Duration
+	Store        Store
+	Ratings      *Ratings
+	Tournaments  *Tournaments
+	AbandonAfter time.Duration
+
+	mu    sync.Mutex
+	games map[string]*registryEntry
+
+	// saveMu keeps the stored games in the order they were changed
+	saveMu sync.Mutex
+
+	// rematchMu makes sure every game gets at most one rematch
+	rematchMu sync.Mutex
+}
+
+type registryEntry struct {
+	service    *GameService
+	lastActive time.Time
+}
+
+// NewRegistry creates an empty registry which evicts games after ttl
+func NewRegistry(ttl time.Duration, store Store) *Registry {
+	return &Registry{
+		TTL:   ttl,
+		Store: store,
+		games: map[string]*registryEntry{},
+	}
+}
+
+// Load adds every game in the store to the registry and returns how many
+// there were.  Games which fail to load are logged and skipped.
+func (r *Registry) Load() (int, error) {
+	ids, err := r.Store.List()
+	if err != nil {
+		return 0, err
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	loaded := 0
+
+	for _, id := range ids {
+		record, err := r.Store.Load(id)
+		if err != nil {
+			log.Printf("[ERROR] %v\n", err)
+			continue
+		}
+
+		service, err := RestoreGameService(record)
+		if err != nil {
+			log.Printf("[ERROR] %v\n", errors.Wrapf(err, "failed to restore game %s", id))
+			continue
+		}
+
+		r.games[id] = &registryEntry{
+			service:    service,
+			lastActive: record.LastActive,
+		}
+		loaded++
+	}
+
+	return loaded, nil
+}
+
+// Create adds the game to the registry and returns it with its ID
+func (r *Registry) Create(game *Game) (string, *GameService, error) {
+	service := NewGameService(game)
+
+	id, err := r.add(service)
+	if err != nil {
+		return "", nil, err
+	}
+
+	return id, service, nil
+}
+
+// Rematch starts the next game of the series the finished game with the
+// given ID is part of and returns it with its ID, see GameService.Rematch.
+// Once started asking again returns the same game.
+func (r *Registry) Rematch(id string, bestOf int) (string, *GameService, error) {
+	r.rematchMu.Lock()
+	defer r.rematchMu.Unlock()
+
+	service, ok := r.Get(id)
+	if !ok {
+		return "", nil, newGameError(ErrNotFound, "game not found: %s", id)
+	}
+
+	// a rematch which has since been evicted is started again
+	if next := service.NextGame(); next != "" {
+		if rematch, ok := r.Get(next); ok {
+			return next, rematch, nil
+		}
+	}
+
+	rematch, err := service.Rematch(id, bestOf)
+	if err != nil {
+		return "", nil, err
+	}
+
+	next, err := r.add(rematch)
+	if err != nil {
+		return "", nil, err
+	}
+
+	service.LinkRematch(id, next, bestOf)
+	r.Touch(id)
+
+	return next, rematch, nil
+}
+
+// add stores the service under a new ID and returns it
+func (r *Registry) add(service *GameService) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	for {
+		id, err := newGameID()
+		if err != nil {
+			return "", errors.Wrap(err, "failed to generate game id")
+		}
+
+		if _, ok := r.games[id]; ok {
+			continue
+		}
+
+		entry := &registryEntry{
+			service:    service,
+			lastActive: now(),
+		}
+
+		err = r.Store.Create(id, entry.record())
+		if errors.Cause(err) == ErrGameExists {
+			continue
+		}
+
+		if err != nil {
+			return "", err
+		}
+
+		r.games[id] = entry
+
+		return id, nil
+	}
+}
+
+// Get returns the game with the given ID
+func (r *Registry) Get(id string) (*GameService, bool) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	entry, ok := r.games[id]
+	if !ok {
+		return nil, false
+	}
+
+	return entry.service, true
+}
+
+// Touch marks the game as active, postponing its eviction, and saves it, see
+// Save
+func (r *Registry) Touch(id string) {
+	r.save(id, true)
+}
+
+// Save saves the game to the store and rates and reports it if it has just
+// ended, without postponing its eviction.  Failing to save, rate or report is
+// logged as the game carries on in memory.
+func (r *Registry) Save(id string) {
+	r.save(id, false)
+}
+
+func (r *Registry) save(id string, active bool) {
+	r.saveMu.Lock()
+	r.mu.Lock()
+
+	entry, ok := r.games[id]
+	if !ok {
+		r.mu.Unlock()
+		r.saveMu.Unlock()
+		return
+	}
+
+	var result GameResult
+	var ended bool
+
+	if r.Ratings != nil || r.Tournaments != nil {
+		result, ended = entry.service.takeResult()
+	}
+
+	if active {
+		entry.lastActive = now()
+	}
+
+	record := entry.record()
+
+	r.mu.Unlock()
+
+	if err := r.Store.Save(id, record); err != nil {
+		log.Printf("[ERROR] %v\n", err)
+	}
+
+	// reporting the result may create the games of the next round
+	r.saveMu.Unlock()
+
+	if !ended {
+		return
+	}
+
+	if r.Ratings != nil {
+		if err := r.Ratings.Rate(id, result); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+		}
+	}
+
+	if r.Tournaments != nil {
+		if err := r.Tournaments.Report(id, result); err != nil {
+			log.Printf("[ERROR] %v\n", err)
+		}
+	}
+}
+
+// List returns the IDs of all active games in sorted order
+func (r *Registry) List() []string {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	ids := make([]string, 0, len(r.games))
+	for id := range r.games {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids
+}
+
+// Evict removes every game which has not seen any activity within the TTL,
+// whether it is finished or was simply abandoned.  It returns the number of
+// games removed.
+func (r *Registry) Evict() int {
+	r.saveMu.Lock()
+	defer r.saveMu.Unlock()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	deadline := now().Add(-r.TTL)
+	evicted := 0
+
+	for id, entry := range r.games {
+		if entry.lastActive.Before(deadline) {
+			delete(r.games, id)
+			evicted++
+
+			if err := r.Store.Delete(id); err != nil {
+				log.Printf("[ERROR] %v\n", err)
+			}
+		}
+	}
+
+	return evicted
+}
+
+// CheckClocks ends the games whose player to move has run out of time, or
+// which have been abandoned, saving and rating them.  It returns the number
+// of games ended.
+func (r *Registry) CheckClocks() int {
+	r.mu.Lock()
+
+	services := make(map[string]*GameService, len(r.games))
+	for id, entry := range r.games {
+		services[id] = entry.service
+	}
+
+	r.mu.Unlock()
+
+	ended := 0
+
+	for id, service := range services {
+		if service.CheckClock() || service.CheckAbandoned(r.AbandonAfter) {
+			r.Touch(id)
+			ended++
+		}
+	}
+
+	return ended
+}
+
+// CheckClocksEvery runs CheckClocks on the given interval until done is
+// closed
+func (r *Registry) CheckClocksEvery(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			r.CheckClocks()
+		case <-done:
+			return
+		}
+	}
+}
+
+// EvictEvery runs Evict on the given interval until done is closed
+func (r *Registry) EvictEvery(interval time.Duration, done <-chan struct{}) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ticker.C:
+			if n := r.Evict(); n > 0 {
+				log.Printf("[INFO] evicted %d games\n", n)
+			}
+		case <-done:
+			return
+		}
+	}
+}
+
+// record must be called with the registry lock held
+func (e *registryEntry) record() GameRecord {
+	record := e.service.Record()
+	record.LastActive = e.lastActive
+
+	return record
+}
+
+func newGameID() (string, error) {
+	b := make([]byte, 8)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
@@ -0,0 +1,201 @@
+package main
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+)
+
+func TestRegistry_Create(t *testing.T) {
+	registry := NewRegistry(time.Minute, NewMemoryStore())
+
+	id1, game1, err := registry.Create(testNewGame())
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	id2, game2, err := registry.Create(testNewGame())
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if id1 == id2 {
+		t.Error("expected unique ids:", id1)
+	}
+
+	if game1 == game2 {
+		t.Error("expected unique games")
+	}
+
+	if status := game1.Snapshot().Status; "alive" != status {
+		t.Error("unexpected status:", status)
+	}
+
+	if game, ok := registry.Get(id1); !ok || game != game1 {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if _, ok := registry.Get("nope"); ok {
+		t.Error("expected missing game")
+	}
+}
+
+func TestRegistry_List(t *testing.T) {
+	registry := NewRegistry(time.Minute, NewMemoryStore())
+
+	if ids := registry.List(); 0 != len(ids) {
+		t.Errorf("unexpected ids: %#v", ids)
+	}
+
+	id1, _, _ := registry.Create(testNewGame())
+	id2, _, _ := registry.Create(testNewGame())
+
+	expectedIDs := []string{id1, id2}
+	sort.Strings(expectedIDs)
+
+	if ids := registry.List(); !reflect.DeepEqual(expectedIDs, ids) {
+		t.Errorf("unexpected ids: %#v", ids)
+	}
+}
+
+func TestRegistry_Evict(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	current := time.Unix(1000, 0)
+	now = func() time.Time {
+		return current
+	}
+
+	registry := NewRegistry(time.Minute, NewMemoryStore())
+
+	idleID, _, _ := registry.Create(testNewGame())
+	activeID, _, _ := registry.Create(testNewGame())
+
+	current = current.Add(45 * time.Second)
+	registry.Touch(activeID)
+
+	// saving a refused request doesn't keep the game around
+	registry.Save(idleID)
+
+	current = current.Add(30 * time.Second)
+
+	if n := registry.Evict(); 1 != n {
+		t.Error("unexpected evicted:", n)
+	}
+
+	if _, ok := registry.Get(idleID); ok {
+		t.Error("expected idle game to be evicted")
+	}
+
+	if _, ok := registry.Get(activeID); !ok {
+		t.Error("expected active game to be kept")
+	}
+
+	current = current.Add(time.Minute)
+
+	if n := registry.Evict(); 1 != n {
+		t.Error("unexpected evicted:", n)
+	}
+
+	if ids := registry.List(); 0 != len(ids) {
+		t.Errorf("unexpected ids: %#v", ids)
+	}
+}
+
+func TestRegistry_Load(t *testing.T) {
+	store := NewMemoryStore()
+	registry := NewRegistry(time.Minute, store)
+
+	id, service, _ := registry.Create(testNewGame())
+
+	_, token, _ := service.Join(1)
+	_, _ = service.MakeMove(1, 1)
+
+	registry.Touch(id)
+
+	restarted := NewRegistry(time.Minute, store)
+
+	if n, err := restarted.Load(); err != nil || 1 != n {
+		t.Fatalf("unexpected loaded: %d %v", n, err)
+	}
+
+	restored, ok := restarted.Get(id)
+	if !ok {
+		t.Fatal("expected game to be loaded")
+	}
+
+	expected := newDefaultResponseModel(id, service.Snapshot())
+
+	if responseModel := newDefaultResponseModel(id, restored.Snapshot()); !reflect.DeepEqual(expected, responseModel) {
+		t.Errorf("unexpected state: %#v", responseModel)
+	}
+
+	if seat, err := restored.Authorize(token); err != nil || 1 != seat {
+		t.Errorf("unexpected seat: %d %v", seat, err)
+	}
+
+	if missed, _, _ := restored.Resume(0); 1 != len(missed) || 1 != missed[0].ID {
+		t.Errorf("unexpected missed events: %#v", missed)
+	}
+
+	restarted.TTL = -time.Minute
+	restarted.Evict()
+
+	if ids, _ := store.List(); 0 != len(ids) {
+		t.Errorf("unexpected stored ids: %#v", ids)
+	}
+}
+
+func testNewGame() *Game {
+	game := &Game{}
+	game.Reset()
+	return game
+}
+
+func TestRegistry_CheckClocks(t *testing.T) {
+	defer func() {
+		now = time.Now
+	}()
+
+	current := time.Unix(1000, 0)
+	now = func() time.Time {
+		return current
+	}
+
+	store := NewMemoryStore()
+	registry := NewRegistry(time.Minute, store)
+
+	clocked := testNewGame()
+	_ = clocked.SetClock(10*time.Second, 0, 0)
+
+	id, service, _ := registry.Create(clocked)
+	registry.Create(testNewGame())
+
+	service.MakeMove(0, 0)
+
+	events, unsubscribe := service.Subscribe()
+	defer unsubscribe()
+
+	current = current.Add(5 * time.Second)
+
+	if n := registry.CheckClocks(); 0 != n {
+		t.Error("unexpected ended:", n)
+	}
+
+	current = current.Add(5 * time.Second)
+
+	if n := registry.CheckClocks(); 1 != n {
+		t.Error("unexpected ended:", n)
+	}
+
+	if event := <-events; EventTimeout != event.Type || StatusTimeout != event.Game.Status {
+		t.Error("unexpected event:", event.Type, event.Game.Status)
+	}
+
+	if record, _ := store.Load(id); StatusTimeout != record.Game.Status || 1 != record.Game.Winner {
+		t.Error("unexpected stored game:", record.Game.Status, record.Game.Winner)
+	}
+}
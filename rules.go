@@ -0,0 +1,280 @@
+package main
+
+import (
+	"sort"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Rules decide what a game is played on, which moves are legal, what a move
+// does and how the game ends.  Every game is played by the rules registered
+// under its Variant so games played by different rules can run side by side
+// on the same server.  Rules hold no state of their own, everything they need
+// is kept in the Game.
+type Rules interface {
+	// Name is the name the rules are registered under
+	Name() string
+
+	// Setup validates the board settings, filling in the defaults for zero
+	// values, and returns the reset which starts a game
+	Setup(width, height, winLength int) (Action, error)
+
+	// Start lays out the board for the reset
+	Start(game *Game, reset Action)
+
+	// Moves lists the cells the player to move may play
+	Moves(game *Game) []Cell
+
+	// CheckMove determines if the player to move may play the cell
+	CheckMove(game *Game, cell Cell) error
+
+	// Apply marks the move, which has just been added to the History
+	Apply(game *Game, move Move)
+
+	// Revert takes back the move, which has just been removed from the History
+	Revert(game *Game, move Move)
+
+	// Outcome determines if the player has won and the line they won with
+	Outcome(game *Game, player int) (Line, bool)
+
+	// Terminal determines if there are no moves left to make
+	Terminal(game *Game) bool
+}
+
+// Dropper is implemented by rules where a mark lands in a cell decided by its
+// column, moves can then be made by column alone
+type Dropper interface {
+	// Land is the cell a mark dropped in column x lands in, its Y is -1 when
+	// the column is full or off the board
+	Land(game *Game, x int) Cell
+}
+
+var (
+	rulesMu         sync.RWMutex
+	registeredRules = map[string]Rules{
+		VariantStandard: StandardRules{},
+		VariantUltimate: UltimateRules{},
+		VariantQubic:    QubicRules{},
+		VariantGravity:  GravityRules{},
+	}
+)
+
+// RegisterRules makes the rules available to new games under their name
+func RegisterRules(rules Rules) error {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+
+	name := rules.Name()
+
+	if name == "" {
+		return newGameError(ErrInvalidSettings, "rules must be named")
+	}
+
+	if _, ok := registeredRules[name]; ok {
+		return newGameError(ErrNameTaken, "rules already registered: %s", name)
+	}
+
+	registeredRules[name] = rules
+
+	return nil
+}
+
+// LookupRules finds the rules registered under the name
+func LookupRules(name string) (Rules, error) {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	rules, ok := registeredRules[name]
+	if !ok {
+		return nil, newGameError(ErrInvalidSettings, "unknown rules: %s", name)
+	}
+
+	return rules, nil
+}
+
+// RulesNames lists the names of the registered rules in order
+func RulesNames() []string {
+	rulesMu.RLock()
+	defer rulesMu.RUnlock()
+
+	names := make([]string, 0, len(registeredRules))
+
+	for name := range registeredRules {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// missingRules stand in for rules a game was played by which are no longer
+// registered, they refuse every move
+type missingRules struct {
+	name string
+}
+
+func (r missingRules) Name() string {
+	return r.name
+}
+
+func (r missingRules) Setup(width, height, winLength int) (Action, error) {
+	return Action{}, r.err()
+}
+
+func (missingRules) Start(game *Game, reset Action) {}
+
+func (missingRules) Moves(game *Game) []Cell {
+	return nil
+}
+
+func (r missingRules) CheckMove(game *Game, cell Cell) error {
+	return errors.Wrap(r.err(), "invalid move")
+}
+
+func (missingRules) Apply(game *Game, move Move) {}
+
+func (missingRules) Revert(game *Game, move Move) {}
+
+func (missingRules) Outcome(game *Game, player int) (Line, bool) {
+	return Line{}, false
+}
+
+func (missingRules) Terminal(game *Game) bool {
+	return false
+}
+
+func (r missingRules) err() error {
+	return newGameError(ErrInvalidSettings, "unknown rules: %s", r.name)
+}
+
+// StandardRules play a m,n,k-game, winLength marks in a row along a column,
+// row or diagonal of a width x height board win.  The Board is indexed as
+// Board[x][y].
+type StandardRules struct{}
+
+// Name is VariantStandard
+func (StandardRules) Name() string {
+	return VariantStandard
+}
+
+// Setup takes the defaults of classic tic-tac-toe
+func (StandardRules) Setup(width, height, winLength int) (Action, error) {
+	if width == 0 {
+		width = DefaultBoardSize
+	}
+
+	if height == 0 {
+		height = DefaultBoardSize
+	}
+
+	if winLength == 0 {
+		winLength = DefaultWinLength
+	}
+
+	if width < 1 || width > MaxBoardSize {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid width: %d", width)
+	}
+
+	if height < 1 || height > MaxBoardSize {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid height: %d", height)
+	}
+
+	if winLength < 1 || (winLength > width && winLength > height) {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid win length: %d", winLength)
+	}
+
+	return Action{Type: ActionReset, Width: width, Height: height, WinLength: winLength}, nil
+}
+
+// Start lays out an empty Board
+func (StandardRules) Start(game *Game, reset Action) {
+	game.Board = newBoard(reset.Width, reset.Height)
+}
+
+// Moves lists the empty cells
+func (StandardRules) Moves(game *Game) []Cell {
+	var cells []Cell
+
+	for x := range game.Board {
+		for y := range game.Board[x] {
+			if game.Board[x][y] == 0 {
+				cells = append(cells, Cell{X: x, Y: y})
+			}
+		}
+	}
+
+	return cells
+}
+
+// CheckMove determines if the cell is an empty one on the board
+func (StandardRules) CheckMove(game *Game, cell Cell) error {
+	if cell.Z != 0 {
+		return errors.Wrap(newGameError(ErrOutOfBounds, "invalid z index: %d", cell.Z), "invalid move")
+	}
+
+	if err := isValidMove(game.Board, cell.X, cell.Y); err != nil {
+		return errors.Wrap(err, "invalid move")
+	}
+
+	return nil
+}
+
+// Apply marks the cell for the player who moved
+func (StandardRules) Apply(game *Game, move Move) {
+	game.Board[move.X][move.Y] = move.Player
+}
+
+// Revert empties the cell again
+func (StandardRules) Revert(game *Game, move Move) {
+	game.Board[move.X][move.Y] = 0
+}
+
+// Outcome runs the win checks over the board
+func (StandardRules) Outcome(game *Game, player int) (Line, bool) {
+	return isWin(game.Board, game.WinLength, player)
+}
+
+// Terminal determines if the board is full
+func (StandardRules) Terminal(game *Game) bool {
+	return isBoardFull(game.Board)
+}
+
+// OrderMoves searches the moves nearest the centre first, on large boards
+// only those near the marks already played
+func (StandardRules) OrderMoves(game *Game, moves []Cell) []Cell {
+	if game.Width()*game.Height() > 16 && game.NumMoves > 0 {
+		var nearby []Cell
+
+		for _, cell := range moves {
+			if hasNeighbour(game.Board, cell.X, cell.Y, 2) {
+				nearby = append(nearby, cell)
+			}
+		}
+
+		moves = nearby
+	}
+
+	sortByCentre(game, moves)
+
+	return moves
+}
+
+// fixedSetup validates settings against the reset of rules which are always
+// played on the same board, they may only be left out or match it
+func fixedSetup(reset Action, width, height, winLength int) (Action, error) {
+	if width != 0 && width != reset.Width {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid width for %s: %d", reset.Variant, width)
+	}
+
+	if height != 0 && height != reset.Height {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid height for %s: %d", reset.Variant, height)
+	}
+
+	if winLength != 0 && winLength != reset.WinLength {
+		return Action{}, newGameError(ErrInvalidSettings, "invalid win length for %s: %d", reset.Variant, winLength)
+	}
+
+	return reset, nil
+}
@@ -0,0 +1,179 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+// noWinRules play on a standard board where nobody ever completes a line
+type noWinRules struct {
+	StandardRules
+}
+
+func (noWinRules) Name() string {
+	return "no-win"
+}
+
+func (noWinRules) Outcome(game *Game, player int) (Line, bool) {
+	return Line{}, false
+}
+
+// stubRules play on a standard board with the move and win checks stubbed out
+type stubRules struct {
+	StandardRules
+	checkMove func(board [][]int, x, y int) error
+	outcome   func(board [][]int, winLength, player int) (Line, bool)
+}
+
+func (stubRules) Name() string {
+	return "stub"
+}
+
+func (r stubRules) CheckMove(game *Game, cell Cell) error {
+	return r.checkMove(game.Board, cell.X, cell.Y)
+}
+
+func (r stubRules) Outcome(game *Game, player int) (Line, bool) {
+	return r.outcome(game.Board, game.WinLength, player)
+}
+
+// registerTestRules registers the rules for the length of a test, the
+// returned func unregisters them again
+func registerTestRules(t *testing.T, rules Rules) func() {
+	if err := RegisterRules(rules); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	return func() {
+		unregisterRules(rules.Name())
+	}
+}
+
+func unregisterRules(name string) {
+	rulesMu.Lock()
+	defer rulesMu.Unlock()
+
+	delete(registeredRules, name)
+}
+
+func TestRegisterRules(t *testing.T) {
+	for _, name := range []string{VariantStandard, VariantUltimate, VariantQubic} {
+		if rules, err := LookupRules(name); err != nil || name != rules.Name() {
+			t.Errorf("unexpected rules: %#v %v", rules, err)
+		}
+	}
+
+	if _, err := LookupRules("quantum"); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := RegisterRules(StandardRules{}); ErrNameTaken != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	defer registerTestRules(t, noWinRules{})()
+
+	names := RulesNames()
+
+	for i := 1; i < len(names); i++ {
+		if names[i-1] >= names[i] {
+			t.Fatal("unexpected names:", names)
+		}
+	}
+
+	game, err := NewVariantGame("no-win", 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	// the top row would have won a standard game
+	for _, move := range [][2]int{{0, 0}, {1, 1}, {1, 0}, {2, 2}, {2, 0}} {
+		_ = game.MakeMove(move[0], move[1])
+	}
+
+	if StatusAlive != game.Status || "no-win" != game.Variant {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if replayed := ReplayGame(game.Log); "no-win" != replayed.Rules().Name() {
+		t.Error("unexpected rules:", replayed.Rules().Name())
+	}
+
+	// resetting keeps the rules
+	game.Reset()
+
+	if "no-win" != game.Rules().Name() || 3 != game.Width() {
+		t.Errorf("unexpected game: %#v", game)
+	}
+}
+
+func TestGame_Rules_Missing(t *testing.T) {
+	unregister := registerTestRules(t, noWinRules{})
+
+	game, err := NewVariantGame("no-win", 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	_ = game.MakeMove(0, 0)
+
+	unregister()
+
+	// games played by rules no longer registered can't carry on by others
+	if "no-win" != game.Rules().Name() {
+		t.Error("unexpected rules:", game.Rules().Name())
+	}
+
+	if err := game.MakeMove(1, 1); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.Reset(); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if "no-win" != game.Variant || 1 != game.NumMoves {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if _, err := RestoreGameService(GameRecord{Game: game}); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestGameService_Rules_Concurrent(t *testing.T) {
+	var wg sync.WaitGroup
+
+	for _, variant := range []string{VariantStandard, VariantUltimate, VariantQubic, VariantStandard} {
+		game, err := NewVariantGame(variant, 0, 0, 0)
+		if err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+
+		wg.Add(1)
+
+		go func(service *GameService) {
+			defer wg.Done()
+
+			snapshot := service.Snapshot()
+
+			for snapshot.Status == StatusAlive {
+				cell := snapshot.Rules().Moves(&snapshot.Game)[0]
+
+				var err error
+				if snapshot, err = service.MakeMoveAt(0, cell.X, cell.Y, cell.Z); err != nil {
+					t.Error("unexpected err:", err)
+					return
+				}
+			}
+
+			if variant := snapshot.Rules().Name(); 0 == snapshot.NumMoves || snapshot.NumMoves > 81 {
+				t.Errorf("%s> unexpected moves: %d", variant, snapshot.NumMoves)
+			}
+		}(NewGameService(game))
+	}
+
+	wg.Wait()
+}
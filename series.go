@@ -0,0 +1,65 @@
+package main
+
+// MaxBestOf is the longest series which may be played
+const MaxBestOf = 99
+
+// Series links the games played by the same players one after another.  The
+// players swap seats every game so each takes their turn to move first.  ID
+// is the first game of the series and Number counts the games from 1.
+//
+// Score holds the results of the games before this one, indexed by the seat
+// the players have in this game with the draws at zero like the Winner.  A
+// series with a BestOf is over once a player has won most of its games,
+// draws are played again.
+type Series struct {
+	ID       string `json:"id"`
+	BestOf   int    `json:"bestOf"`
+	Number   int    `json:"number"`
+	Previous string `json:"previous,omitempty"`
+	Next     string `json:"next,omitempty"`
+	Score    [3]int `json:"score"`
+}
+
+// Standing is the score of the series including the game, once it has ended
+func (s *Series) Standing(game *Game) [3]int {
+	score := s.Score
+
+	if game.isOver() {
+		score[game.Winner]++
+	}
+
+	return score
+}
+
+// Winner is the seat of the player who won the series given its standing,
+// zero while it is still being played
+func (s *Series) Winner(standing [3]int) int {
+	if s.BestOf == 0 {
+		return 0
+	}
+
+	for player := 1; player <= 2; player++ {
+		if standing[player] > s.BestOf/2 {
+			return player
+		}
+	}
+
+	return 0
+}
+
+// next is the series as seen from the following game, the seats swap
+func (s *Series) next(id string, standing [3]int) *Series {
+	return &Series{
+		ID:       s.ID,
+		BestOf:   s.BestOf,
+		Number:   s.Number + 1,
+		Previous: id,
+		Score:    [3]int{standing[0], standing[2], standing[1]},
+	}
+}
+
+// isValidBestOf allows an odd number of games so the series can't be tied,
+// zero plays on without end
+func isValidBestOf(bestOf int) bool {
+	return bestOf == 0 || (bestOf > 0 && bestOf <= MaxBestOf && bestOf%2 == 1)
+}
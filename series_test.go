@@ -0,0 +1,93 @@
+package main
+
+import (
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func TestRegistry_Rematch(t *testing.T) {
+	registry := NewRegistry(time.Minute, NewMemoryStore())
+
+	id, service, _ := registry.Create(testNewGame())
+	_, xToken, _ := service.Join(1)
+	_, oToken, _ := service.Join(2)
+
+	if _, _, err := registry.Rematch(id, 3); ErrGameInProgress != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	// X wins the first game
+	for _, move := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}} {
+		service.MakeMove(move[0], move[1])
+	}
+
+	if _, _, err := registry.Rematch(id, 4); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	next, rematch, err := registry.Rematch(id, 3)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	// the players swap seats
+	if seat, _ := rematch.Authorize(xToken); 2 != seat {
+		t.Error("unexpected seat:", seat)
+	}
+
+	if seat, _ := rematch.Authorize(oToken); 1 != seat {
+		t.Error("unexpected seat:", seat)
+	}
+
+	if again, _, _ := registry.Rematch(id, 3); next != again {
+		t.Error("unexpected rematch:", again)
+	}
+
+	if series := service.Snapshot().Series; nil == series || next != series.Next || 1 != series.Number {
+		t.Errorf("unexpected series: %#v", series)
+	}
+
+	expected := Series{ID: id, BestOf: 3, Number: 2, Previous: id, Score: [3]int{0, 0, 1}}
+	if series := rematch.Snapshot().Series; nil == series || expected != *series {
+		t.Errorf("unexpected series: %#v", series)
+	}
+
+	// the first X, now O, wins the series
+	for _, move := range [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 2}, {1, 2}} {
+		rematch.MakeMove(move[0], move[1])
+	}
+
+	game := rematch.Snapshot()
+
+	if standing := game.Series.Standing(&game.Game); [3]int{0, 0, 2} != standing || 2 != game.Series.Winner(standing) {
+		t.Error("unexpected standing:", standing)
+	}
+
+	if _, _, err := registry.Rematch(next, 0); ErrSeriesOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestSeries_Winner(t *testing.T) {
+	tests := []struct {
+		BestOf   int
+		Standing [3]int
+		Winner   int
+	}{
+		{0, [3]int{0, 9, 0}, 0},
+		{3, [3]int{0, 1, 1}, 0},
+		{3, [3]int{4, 1, 1}, 0},
+		{3, [3]int{0, 2, 0}, 1},
+		{5, [3]int{1, 1, 3}, 2},
+	}
+
+	for i, test := range tests {
+		series := Series{BestOf: test.BestOf}
+
+		if winner := series.Winner(test.Standing); test.Winner != winner {
+			t.Errorf("%d> unexpected winner: %d", i, winner)
+		}
+	}
+}
@@ -0,0 +1,1048 @@
+package main
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"sync"
+	"time"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+	"github.com/pkg/errors"
+)
+
+// GameService guards a Game so it can be shared by the concurrent HTTP
+// handlers.  Every mutation is atomic and returns a snapshot of the game
+// taken while the lock was still held.  Each change is published to the
+// subscribers as a numbered event.  When a bot is set it answers every move
+// as soon as it is its turn.
+type GameService struct {
+	mu   sync.Mutex
+	game *Game
+
+	bot       *ai.Player
+	botPlayer int
+
+	// tokens holds the token of the player in each seat, indexed by player,
+	// and names the account of those who joined with one
+	tokens [3]string
+	names  [3]string
+
+	// rated is set once the result of the game has been taken for rating
+	rated bool
+
+	// series is set once a rematch has been played
+	series *Series
+
+	// connections counts the live connections of the player in each seat,
+	// and those of the watchers at zero, and disconnected is when the last
+	// of a player's closed
+	connections  [3]int
+	disconnected [3]time.Time
+
+	subscribers map[chan GameEvent]struct{}
+
+	// lastEventID numbers the events, moveEventIDs holds the ID of the event
+	// for each move in the history, rewindEventID the last reset, undo or draw
+	// offer and endEventID however the game ended.
+	lastEventID   int
+	moveEventIDs  []int
+	rewindEventID int
+	endEventID    int
+}
+
+// Kinds of game events
+const (
+	EventMove         = "move"
+	EventReset        = "reset"
+	EventUndo         = "undo"
+	EventWin          = "win"
+	EventDraw         = "draw"
+	EventTimeout      = "timeout"
+	EventResign       = "resign"
+	EventAgreedDraw   = "agreed-draw"
+	EventAbandon      = "abandon"
+	EventDrawOffer    = "draw-offer"
+	EventDrawDeclined = "draw-declined"
+	EventRematch      = "rematch"
+	EventWatchers     = "watchers"
+)
+
+// GameEvent is published to subscribers whenever the game changes.  Move is
+// set for move events while Game is the state once the event happened.
+type GameEvent struct {
+	ID   int
+	Type string
+	Move *Move
+	Game GameSnapshot
+}
+
+// subscriberBuffer is how many events a subscriber may fall behind by before
+// it is unsubscribed
+const subscriberBuffer = 16
+
+// GameSnapshot is a copy of the game along with how it is being played, the
+// Log is left out, see GameService.Log.  Seats lists the players who have
+// joined, including the bot, and Watchers counts the connections of everyone
+// else.
+type GameSnapshot struct {
+	Game
+	Mode       string
+	BotPlayer  int
+	Difficulty ai.Level
+	Seats      []int
+	Names      [3]string
+	Series     *Series
+	Watchers   int
+}
+
+// NewGameService wraps the game, which must no longer be used directly
+func NewGameService(game *Game) *GameService {
+	return &GameService{game: game}
+}
+
+// RestoreGameService carries on with a stored game, games played by rules
+// which are no longer registered or with a corrupt log can't be carried on
+func RestoreGameService(record GameRecord) (*GameService, error) {
+	game := record.Game
+
+	// games saved before the log was kept are carried on as they are
+	if len(game.Log) > 0 {
+		var err error
+		if game, err = replayLog(game.Log); err != nil {
+			return nil, err
+		}
+	}
+
+	if _, err := LookupRules(game.variant()); err != nil {
+		return nil, err
+	}
+
+	s := &GameService{
+		game:          game,
+		tokens:        record.Tokens,
+		names:         record.Names,
+		rated:         record.Rated,
+		series:        record.Series,
+		lastEventID:   record.Events.LastID,
+		moveEventIDs:  record.Events.MoveIDs,
+		rewindEventID: record.Events.RewindID,
+		endEventID:    record.Events.EndID,
+	}
+
+	if record.Bot != nil {
+		s.bot = ai.NewPlayer(record.Bot.Difficulty)
+		s.bot.BlunderRate = record.Bot.BlunderRate
+		s.botPlayer = record.Bot.Player
+	}
+
+	return s, nil
+}
+
+// Record returns a copy of the game in the form it is stored
+func (s *GameService) Record() GameRecord {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	record := GameRecord{
+		Game:   s.game.Clone(),
+		Tokens: s.tokens,
+		Names:  s.names,
+		Rated:  s.rated,
+		Series: s.copySeries(),
+		Events: EventRecord{
+			LastID:   s.lastEventID,
+			MoveIDs:  append([]int(nil), s.moveEventIDs...),
+			RewindID: s.rewindEventID,
+			EndID:    s.endEventID,
+		},
+	}
+
+	if s.bot != nil {
+		record.Bot = &BotRecord{
+			Player:      s.botPlayer,
+			Difficulty:  s.bot.Level,
+			BlunderRate: s.bot.BlunderRate,
+		}
+	}
+
+	return record
+}
+
+// SetBot has the bot play as botPlayer, moving straight away if it is its
+// turn.
+func (s *GameService) SetBot(bot *ai.Player, botPlayer int) (GameSnapshot, error) {
+	if botPlayer != 1 && botPlayer != 2 {
+		return GameSnapshot{}, newGameError(ErrInvalidSettings, "invalid bot player: %d", botPlayer)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens[botPlayer] != "" {
+		return GameSnapshot{}, newGameError(ErrSeatTaken, "seat taken: %d", botPlayer)
+	}
+
+	s.bot = bot
+	s.botPlayer = botPlayer
+
+	if err := s.botMove(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	return s.snapshot(), nil
+}
+
+// Join seats a player, returning their seat and the token to move with.  A
+// zero seat takes whichever is free, X before O.
+func (s *GameService) Join(seat int) (int, string, error) {
+	return s.JoinAs(seat, "")
+}
+
+// JoinAs seats the named player like Join.  Games between two named players
+// are rated once they end.
+func (s *GameService) JoinAs(seat int, name string) (int, string, error) {
+	if seat < 0 || seat > 2 {
+		return 0, "", newGameError(ErrInvalidSettings, "invalid seat: %d", seat)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if seat == 0 {
+		for player := 1; player <= 2 && seat == 0; player++ {
+			if !s.isSeated(player) {
+				seat = player
+			}
+		}
+
+		if seat == 0 {
+			return 0, "", newGameError(ErrSeatTaken, "no free seats")
+		}
+	}
+
+	if s.isSeated(seat) {
+		return 0, "", newGameError(ErrSeatTaken, "seat taken: %d", seat)
+	}
+
+	token, err := newToken()
+	if err != nil {
+		return 0, "", errors.Wrap(err, "failed to create token")
+	}
+
+	s.tokens[seat] = token
+	s.names[seat] = name
+
+	return seat, token, nil
+}
+
+// Authorize returns the seat held by the token.  Until someone joins the game
+// anyone may play for either side, which is reported as seat 0.
+func (s *GameService) Authorize(token string) (int, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.tokens[1] == "" && s.tokens[2] == "" {
+		return 0, nil
+	}
+
+	if token == "" {
+		return 0, newGameError(ErrUnauthorized, "missing token")
+	}
+
+	for player := 1; player <= 2; player++ {
+		if subtle.ConstantTimeCompare([]byte(s.tokens[player]), []byte(token)) == 1 {
+			return player, nil
+		}
+	}
+
+	return 0, newGameError(ErrUnauthorized, "invalid token")
+}
+
+// Subscribe returns a channel receiving every change to the game along with a
+// function to stop receiving them.  The channel is closed once unsubscribed,
+// which also happens when the subscriber falls too far behind.
+func (s *GameService) Subscribe() (<-chan GameEvent, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.subscribe()
+}
+
+// Resume subscribes like Subscribe and also returns the events since
+// lastEventID, rebuilt from the move history.  When the game has been reset
+// or a move undone since then a single reset event with the current state is
+// returned instead.  A negative lastEventID always gets the reset event.
+func (s *GameService) Resume(lastEventID int) ([]GameEvent, <-chan GameEvent, func()) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	events, unsubscribe := s.subscribe()
+
+	if lastEventID >= s.lastEventID && lastEventID >= 0 {
+		return nil, events, unsubscribe
+	}
+
+	snapshot := s.snapshot()
+
+	if lastEventID < s.rewindEventID || lastEventID < 0 {
+		return []GameEvent{{ID: s.lastEventID, Type: EventReset, Game: snapshot}}, events, unsubscribe
+	}
+
+	var missed []GameEvent
+
+	for i, id := range s.moveEventIDs {
+		if id > lastEventID {
+			move := snapshot.History[i]
+			missed = append(missed, GameEvent{ID: id, Type: EventMove, Move: &move, Game: snapshot})
+		}
+	}
+
+	if s.endEventID > lastEventID {
+		missed = append(missed, GameEvent{ID: s.endEventID, Type: endEventType(s.game), Game: snapshot})
+	}
+
+	return missed, events, unsubscribe
+}
+
+// subscribe must be called with the lock held
+func (s *GameService) subscribe() (<-chan GameEvent, func()) {
+	if s.subscribers == nil {
+		s.subscribers = make(map[chan GameEvent]struct{})
+	}
+
+	events := make(chan GameEvent, subscriberBuffer)
+	s.subscribers[events] = struct{}{}
+
+	return events, func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.unsubscribe(events)
+	}
+}
+
+// unsubscribe must be called with the lock held
+func (s *GameService) unsubscribe(events chan GameEvent) {
+	if _, ok := s.subscribers[events]; ok {
+		delete(s.subscribers, events)
+		close(events)
+	}
+}
+
+// Snapshot returns a copy of the current game state
+func (s *GameService) Snapshot() GameSnapshot {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.snapshot()
+}
+
+// Replay returns a copy of the game started by the reset-th reset as it was
+// once upto moves had been made, see Game.Replay
+func (s *GameService) Replay(reset, upto int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	game, err := s.game.Replay(reset, upto)
+	if err != nil {
+		return GameSnapshot{}, err
+	}
+
+	snapshot := s.snapshot()
+	snapshot.Game = *game
+	snapshot.Log = nil
+
+	return snapshot, nil
+}
+
+// Log returns a copy of at most limit actions of the log, starting from the
+// from-th, along with how many actions the log holds
+func (s *GameService) Log(from, limit int) ([]Action, int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	total := len(s.game.Log)
+
+	if from > total {
+		from = total
+	}
+
+	to := from + limit
+	if to > total {
+		to = total
+	}
+
+	return append([]Action(nil), s.game.Log[from:to]...), total
+}
+
+// takeResult returns the result of a game between two named players the first
+// time it is called after the game ended, so it is rated only once.  Starting
+// the game over makes it rated again.
+func (s *GameService) takeResult() (GameResult, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.rated || s.game.Status == StatusAlive || s.names[1] == "" || s.names[2] == "" {
+		return GameResult{}, false
+	}
+
+	s.rated = true
+
+	return GameResult{Names: s.names, Winner: s.game.Winner}, true
+}
+
+// MakeMove makes the move at x, y and returns the resulting state
+func (s *GameService) MakeMove(x, y int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.move(func() error {
+		return s.game.MakeMoveAs(s.game.Player, x, y)
+	})
+}
+
+// MakeMoveAs makes the move at x, y on behalf of player and returns the
+// resulting state
+func (s *GameService) MakeMoveAs(player, x, y int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.move(func() error {
+		return s.game.MakeMoveAs(player, x, y)
+	})
+}
+
+// MakeMoveAt makes the move at x, y on layer z on behalf of player, or the
+// player whose turn it is when zero, and returns the resulting state
+func (s *GameService) MakeMoveAt(player, x, y, z int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if player == 0 {
+		player = s.game.Player
+	}
+
+	return s.move(func() error {
+		return s.game.MakeMoveAt(player, x, y, z)
+	})
+}
+
+// Drop makes the move in column x on behalf of player, see Game.Drop
+func (s *GameService) Drop(player, x int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if player == 0 {
+		player = s.game.Player
+	}
+
+	return s.move(func() error {
+		return s.game.Drop(player, x)
+	})
+}
+
+// move makes the move with play and has the bot reply, it must be called with
+// the lock held
+func (s *GameService) move(play func() error) (GameSnapshot, error) {
+	s.checkClock()
+
+	if err := play(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	s.played()
+
+	if err := s.botMove(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	return s.snapshot(), nil
+}
+
+// Reset starts the game over and returns the resulting state, see Restart
+func (s *GameService) Reset() (GameSnapshot, error) {
+	return s.Restart(Settings{})
+}
+
+// Undo takes back the last move on behalf of player and returns the
+// resulting state.  Only the player who made the move may take it back, any
+// player may while nobody has taken a seat in which case player is zero.
+// Moves can't be taken back while a game between two seated players is being
+// played, the opponent's position never changes under them.  Against a bot
+// its reply is taken back too so it is the human's turn again.
+func (s *GameService) Undo(player int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.checkSettled(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	if s.inProgress() {
+		return GameSnapshot{}, newGameError(ErrGameInProgress, "game in progress, moves can't be taken back while both seats are taken")
+	}
+
+	if mover := s.lastMover(); player != 0 && mover != 0 && mover != player {
+		return GameSnapshot{}, newGameError(ErrWrongSeat, "only player %d may take back their move", mover)
+	}
+
+	if err := s.game.Undo(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	if s.isBotTurn() {
+		if err := s.game.Undo(); err != nil {
+			// only the bot's opening move was left, put it back
+			if redoErr := s.game.Redo(); redoErr != nil {
+				return GameSnapshot{}, errors.Wrap(redoErr, "failed to restore bot move")
+			}
+
+			return GameSnapshot{}, err
+		}
+	}
+
+	s.rewind(EventUndo)
+
+	return s.snapshot(), nil
+}
+
+// Redo replays the last undone move on behalf of player and returns the
+// resulting state, only the player who took the move back may replay it, see
+// Undo.  Against a bot its reply is replayed too, or worked out again if it
+// was never made.
+func (s *GameService) Redo(player int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.checkSettled(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	if s.inProgress() {
+		return GameSnapshot{}, newGameError(ErrGameInProgress, "game in progress, moves can't be replayed while both seats are taken")
+	}
+
+	if undone := s.game.Undone; player != 0 && len(undone) > 0 && undone[len(undone)-1].Player != player {
+		return GameSnapshot{}, newGameError(ErrWrongSeat, "only player %d may replay their move", undone[len(undone)-1].Player)
+	}
+
+	if err := s.game.Redo(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	s.played()
+
+	if s.isBotTurn() && len(s.game.Undone) > 0 {
+		if err := s.game.Redo(); err != nil {
+			return GameSnapshot{}, err
+		}
+
+		s.played()
+	}
+
+	if err := s.botMove(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	return s.snapshot(), nil
+}
+
+// checkSettled refuses to change the result of a game which stands, once a
+// game between two named players has ended it is rated, or reported to its
+// tournament, and can't be taken back or started over.  It must be called
+// with the lock held.
+func (s *GameService) checkSettled() error {
+	if s.game.Status != StatusAlive && s.names[1] != "" && s.names[2] != "" {
+		return newGameError(ErrGameOver, "game over, the result of a game between named players stands")
+	}
+
+	return nil
+}
+
+// inProgress determines if a game between two seated players is being played,
+// neither of them may then take back moves or start it over.  It must be
+// called with the lock held.
+func (s *GameService) inProgress() bool {
+	return s.tokens[1] != "" && s.tokens[2] != "" && s.game.Status == StatusAlive
+}
+
+// lastMover is the player who made the last move, passing over the replies
+// of a bot, or zero when only the bot has moved.  It must be called with the
+// lock held.
+func (s *GameService) lastMover() int {
+	for i := len(s.game.History) - 1; i >= 0; i-- {
+		if player := s.game.History[i].Player; s.bot == nil || player != s.botPlayer {
+			return player
+		}
+	}
+
+	return 0
+}
+
+// Resize starts the game over on a new board and returns the resulting state.
+// Zero values keep the current setting.
+func (s *GameService) Resize(width, height, winLength int) (GameSnapshot, error) {
+	return s.Configure("", width, height, winLength)
+}
+
+// Configure changes the rules, board dimensions and win length then resets
+// the game and returns the resulting state, see Game.Configure
+func (s *GameService) Configure(variant string, width, height, winLength int) (GameSnapshot, error) {
+	return s.Restart(Settings{Rules: variant, Width: width, Height: height, WinLength: winLength})
+}
+
+// Restart starts the game over with the settings and returns the resulting
+// state, see Game.Restart.  A game between two seated players can only be
+// started over once it has ended, and not at all once its result stands.
+func (s *GameService) Restart(settings Settings) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.checkSettled(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	if s.inProgress() {
+		return GameSnapshot{}, newGameError(ErrGameInProgress, "game in progress, it can only be started over once it has ended")
+	}
+
+	if err := s.game.Restart(settings); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	s.rewind(EventReset)
+	s.rated = false
+
+	if err := s.botMove(); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	return s.snapshot(), nil
+}
+
+// CheckClock ends the game once the player to move has run out of time,
+// returning whether it did
+func (s *GameService) CheckClock() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.checkClock()
+}
+
+// checkClock must be called with the lock held
+func (s *GameService) checkClock() bool {
+	if !s.game.CheckClock() {
+		return false
+	}
+
+	s.ended()
+
+	return true
+}
+
+// Resign ends the game as lost for player and returns the resulting state,
+// zero resigns for the player to move
+func (s *GameService) Resign(player int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.game.Resign(player); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	s.ended()
+
+	return s.snapshot(), nil
+}
+
+// OfferDraw offers the opponent of player a draw and returns the resulting
+// state, see Game.OfferDraw.  The bot declines every offer.
+func (s *GameService) OfferDraw(player int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.game.OfferDraw(player); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	if s.game.isDecided() {
+		s.ended()
+		return s.snapshot(), nil
+	}
+
+	if s.bot != nil && s.game.DrawOffer == 3-s.botPlayer {
+		if err := s.game.DeclineDraw(s.botPlayer); err != nil {
+			return GameSnapshot{}, err
+		}
+
+		s.rewind(EventDrawDeclined)
+
+		return s.snapshot(), nil
+	}
+
+	s.rewind(EventDrawOffer)
+
+	return s.snapshot(), nil
+}
+
+// AcceptDraw ends the game as drawn by agreement and returns the resulting
+// state, see Game.AcceptDraw
+func (s *GameService) AcceptDraw(player int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.game.AcceptDraw(player); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	s.ended()
+
+	return s.snapshot(), nil
+}
+
+// DeclineDraw turns the draw offer down and returns the resulting state, see
+// Game.DeclineDraw
+func (s *GameService) DeclineDraw(player int) (GameSnapshot, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.checkClock()
+
+	if err := s.game.DeclineDraw(player); err != nil {
+		return GameSnapshot{}, err
+	}
+
+	s.rewind(EventDrawDeclined)
+
+	return s.snapshot(), nil
+}
+
+// Connect counts a connection held by the player in seat, or a watcher for
+// seat zero, until the returned function is called.  Once all of a player's
+// connections have closed their seat counts as disconnected.  The watchers
+// coming and going is published.
+func (s *GameService) Connect(seat int) func() {
+	if seat < 0 || seat > 2 {
+		return func() {}
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.connections[seat]++
+	s.disconnected[seat] = time.Time{}
+
+	if seat == 0 {
+		s.publish(EventWatchers, nil)
+	}
+
+	return func() {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.connections[seat]--
+
+		if seat == 0 {
+			s.publish(EventWatchers, nil)
+		} else if s.connections[seat] == 0 {
+			s.disconnected[seat] = now()
+		}
+	}
+}
+
+// CheckAbandoned forfeits the game of a player who has been disconnected, or
+// has had the move without making it, for longer than after.  Only games
+// between two players who joined with a token, and in which the first move
+// has been made, are forfeited.  It returns whether the game was ended.
+func (s *GameService) CheckAbandoned(after time.Duration) bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if after <= 0 || s.game.Status != StatusAlive || len(s.game.History) == 0 || s.tokens[1] == "" || s.tokens[2] == "" {
+		return false
+	}
+
+	cutoff := now().Add(-after)
+	player := 0
+
+	for seat := 1; seat <= 2 && player == 0; seat++ {
+		if !s.disconnected[seat].IsZero() && !s.disconnected[seat].After(cutoff) {
+			player = seat
+		}
+	}
+
+	if player == 0 && !s.game.IdleSince().After(cutoff) {
+		player = s.game.Player
+	}
+
+	if player == 0 {
+		return false
+	}
+
+	if err := s.game.Abandon(player); err != nil {
+		return false
+	}
+
+	s.ended()
+
+	return true
+}
+
+// Rematch sets up the next game of the series the game with the given ID is
+// part of, bestOf games long when it is the first rematch.  The players keep
+// their tokens and names but swap seats, as does the bot, so the other side
+// moves first.  The game must have ended and the series still be undecided.
+// The rematch is only linked to the game once it has an ID of its own, see
+// LinkRematch.
+func (s *GameService) Rematch(id string, bestOf int) (*GameService, error) {
+	if !isValidBestOf(bestOf) {
+		return nil, newGameError(ErrInvalidSettings, "invalid best of: %d", bestOf)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if !s.game.isOver() {
+		return nil, newGameError(ErrGameInProgress, "game in progress")
+	}
+
+	series := s.series
+	if series == nil {
+		series = &Series{ID: id, BestOf: bestOf, Number: 1}
+	}
+
+	standing := series.Standing(s.game)
+
+	if winner := series.Winner(standing); winner != 0 {
+		return nil, newGameError(ErrSeriesOver, "series won by player %d", winner)
+	}
+
+	game, err := NewVariantGame(s.game.variant(), s.game.Width(), s.game.Height(), s.game.WinLength)
+	if err != nil {
+		return nil, err
+	}
+
+	if clock := s.game.Clock; clock != nil {
+		if err := game.SetClock(clock.PerMove, clock.Total, clock.Increment); err != nil {
+			return nil, err
+		}
+	}
+
+	if s.game.Misere {
+		game.SetMisere(true)
+	}
+
+	rematch := &GameService{
+		game:   game,
+		tokens: [3]string{"", s.tokens[2], s.tokens[1]},
+		names:  [3]string{"", s.names[2], s.names[1]},
+		series: series.next(id, standing),
+	}
+
+	if s.bot != nil {
+		rematch.bot = ai.NewPlayer(s.bot.Level)
+		rematch.bot.BlunderRate = s.bot.BlunderRate
+		rematch.botPlayer = 3 - s.botPlayer
+
+		rematch.mu.Lock()
+		defer rematch.mu.Unlock()
+
+		if err := rematch.botMove(); err != nil {
+			return nil, err
+		}
+	}
+
+	return rematch, nil
+}
+
+// LinkRematch records the ID of the game set up by Rematch as the next game
+// of the series and lets the subscribers know
+func (s *GameService) LinkRematch(id, next string, bestOf int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.series == nil {
+		s.series = &Series{ID: id, BestOf: bestOf, Number: 1}
+	}
+
+	s.series.Next = next
+
+	// the state is all there is to the event, resuming gets it as a reset
+	s.rewindEventID = s.publish(EventRematch, nil)
+}
+
+// NextGame is the ID of the game which followed this one in its series
+func (s *GameService) NextGame() string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.series == nil {
+		return ""
+	}
+
+	return s.series.Next
+}
+
+// copySeries must be called with the lock held
+func (s *GameService) copySeries() *Series {
+	if s.series == nil {
+		return nil
+	}
+
+	series := *s.series
+
+	return &series
+}
+
+// isSeated must be called with the lock held
+func (s *GameService) isSeated(player int) bool {
+	return s.tokens[player] != "" || (s.bot != nil && s.botPlayer == player)
+}
+
+// isBotTurn must be called with the lock held
+func (s *GameService) isBotTurn() bool {
+	return s.bot != nil && s.game.Status == StatusAlive && s.game.Player == s.botPlayer
+}
+
+// botMove makes the bot's move when it is its turn, it must be called with
+// the lock held.
+func (s *GameService) botMove() error {
+	if !s.isBotTurn() {
+		return nil
+	}
+
+	s.bot.MaxDepth = botDepth(s.game)
+
+	move, err := s.bot.Move(&botState{game: s.game.state()})
+	if err != nil {
+		return errors.Wrap(err, "bot failed to move")
+	}
+
+	if err := s.game.MakeMoveAt(s.game.Player, move.X, move.Y, move.Z); err != nil {
+		return errors.Wrap(err, "bot made an invalid move")
+	}
+
+	s.played()
+
+	return nil
+}
+
+// played publishes the last move in the history followed by the win or draw
+// it ended the game with, it must be called with the lock held.
+func (s *GameService) played() {
+	move := s.game.History[len(s.game.History)-1]
+
+	s.moveEventIDs = append(s.moveEventIDs, s.publish(EventMove, &move))
+
+	if eventType := endEventType(s.game); eventType != "" {
+		s.endEventID = s.publish(eventType, nil)
+	}
+}
+
+// ended publishes how the game ended other than on the board, it must be
+// called with the lock held.
+func (s *GameService) ended() {
+	s.endEventID = s.publish(endEventType(s.game), nil)
+}
+
+// rewind publishes a reset, undo or change to the draw offer, it must be
+// called with the lock held.
+func (s *GameService) rewind(eventType string) {
+	s.moveEventIDs = s.moveEventIDs[:len(s.game.History)]
+	s.endEventID = 0
+	s.rewindEventID = s.publish(eventType, nil)
+}
+
+// publish sends the next event to every subscriber and returns its ID, it
+// must be called with the lock held.
+func (s *GameService) publish(eventType string, move *Move) int {
+	s.lastEventID++
+
+	if len(s.subscribers) == 0 {
+		return s.lastEventID
+	}
+
+	event := GameEvent{
+		ID:   s.lastEventID,
+		Type: eventType,
+		Move: move,
+		Game: s.snapshot(),
+	}
+
+	for events := range s.subscribers {
+		select {
+		case events <- event:
+		default:
+			s.unsubscribe(events)
+		}
+	}
+
+	return s.lastEventID
+}
+
+// snapshot must be called with the lock held
+func (s *GameService) snapshot() GameSnapshot {
+	snapshot := GameSnapshot{
+		Game:     *s.game.state(),
+		Mode:     ModeHuman,
+		Names:    s.names,
+		Series:   s.copySeries(),
+		Watchers: s.connections[0],
+	}
+
+	if s.bot != nil {
+		snapshot.Mode = ModeBot
+		snapshot.BotPlayer = s.botPlayer
+		snapshot.Difficulty = s.bot.Level
+	}
+
+	for player := 1; player <= 2; player++ {
+		if s.isSeated(player) {
+			snapshot.Seats = append(snapshot.Seats, player)
+		}
+	}
+
+	return snapshot
+}
+
+// endEventType is the event for how the game ended, if it has
+func endEventType(game *Game) string {
+	switch game.Status {
+	case StatusEnd:
+		return EventWin
+	case StatusDraw:
+		return EventDraw
+	case StatusTimeout:
+		return EventTimeout
+	case StatusResigned:
+		return EventResign
+	case StatusAgreedDraw:
+		return EventAgreedDraw
+	case StatusAbandoned:
+		return EventAbandon
+	}
+
+	return ""
+}
+
+func newToken() (string, error) {
+	b := make([]byte, 16)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(b), nil
+}
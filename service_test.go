@@ -0,0 +1,393 @@
+package main
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestGameService_ParallelMoves(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+
+	var (
+		wg        sync.WaitGroup
+		mu        sync.Mutex
+		snapshots []GameSnapshot
+	)
+
+	for i := 0; i < 500; i++ {
+		wg.Add(1)
+
+		go func(i int) {
+			defer wg.Done()
+
+			x, y := i%3, (i/3)%3
+
+			snapshot, err := service.MakeMove(x, y)
+			if err != nil {
+				return
+			}
+
+			if val := snapshot.Board[x][y]; 0 == val {
+				t.Errorf("%d> expected move in snapshot: [%d][%d]", i, x, y)
+			}
+
+			mu.Lock()
+			snapshots = append(snapshots, snapshot)
+			mu.Unlock()
+		}(i)
+	}
+
+	wg.Wait()
+
+	final := service.Snapshot()
+
+	ones, twos := 0, 0
+	for x := 0; x < 3; x++ {
+		for y := 0; y < 3; y++ {
+			switch final.Board[x][y] {
+			case 1:
+				ones++
+			case 2:
+				twos++
+			}
+		}
+	}
+
+	if pieces := ones + twos; len(snapshots) != pieces {
+		t.Errorf("unexpected pieces: %d, accepted moves: %d", pieces, len(snapshots))
+	}
+
+	if diff := ones - twos; diff != 0 && diff != 1 {
+		t.Errorf("unexpected pieces: %d ones, %d twos", ones, twos)
+	}
+
+	seen := map[int]bool{}
+	for _, snapshot := range snapshots {
+		pieces := 0
+		for x := 0; x < 3; x++ {
+			for y := 0; y < 3; y++ {
+				if snapshot.Board[x][y] != 0 {
+					pieces++
+				}
+			}
+		}
+
+		if seen[pieces] {
+			t.Errorf("duplicate snapshot with %d pieces", pieces)
+		}
+		seen[pieces] = true
+	}
+
+	if numMoves := final.NumMoves; len(snapshots) != numMoves {
+		t.Errorf("unexpected numMoves: %d, accepted moves: %d", numMoves, len(snapshots))
+	}
+
+	if status := final.Status; "alive" == status {
+		t.Error("unexpected status:", status)
+	}
+}
+
+func TestGameService_ParallelResets(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+
+	var wg sync.WaitGroup
+
+	for i := 0; i < 300; i++ {
+		wg.Add(3)
+
+		go func(i int) {
+			defer wg.Done()
+			_, _ = service.MakeMove(i%3, (i/3)%3)
+		}(i)
+
+		go func() {
+			defer wg.Done()
+
+			if snapshot, _ := service.Reset(); 0 != snapshot.NumMoves {
+				t.Error("unexpected numMoves:", snapshot.NumMoves)
+			}
+		}()
+
+		go func() {
+			defer wg.Done()
+
+			snapshot := service.Snapshot()
+
+			pieces := 0
+			for x := 0; x < 3; x++ {
+				for y := 0; y < 3; y++ {
+					if snapshot.Board[x][y] != 0 {
+						pieces++
+					}
+				}
+			}
+
+			if pieces != snapshot.NumMoves {
+				t.Errorf("inconsistent snapshot: %d pieces, %d moves", pieces, snapshot.NumMoves)
+			}
+		}()
+	}
+
+	wg.Wait()
+}
+
+func TestGameService_Subscribe(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+
+	events, unsubscribe := service.Subscribe()
+
+	_, _ = service.MakeMove(1, 1)
+	_, _ = service.MakeMove(1, 1)
+	_, _ = service.Reset()
+
+	if event := <-events; "move" != event.Type || 1 != event.Game.NumMoves {
+		t.Errorf("unexpected event: %s %d", event.Type, event.Game.NumMoves)
+	}
+
+	if event := <-events; "reset" != event.Type || 0 != event.Game.NumMoves {
+		t.Errorf("unexpected event: %s %d", event.Type, event.Game.NumMoves)
+	}
+
+	unsubscribe()
+	unsubscribe()
+
+	if _, ok := <-events; ok {
+		t.Error("expected events to be closed")
+	}
+
+	if _, err := service.MakeMove(0, 0); err != nil {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestGameService_Connect_Watchers(t *testing.T) {
+	service := NewGameService(testNewGame())
+
+	events, unsubscribe := service.Subscribe()
+	defer unsubscribe()
+
+	leave := service.Connect(0)
+	service.Connect(0)
+	service.Connect(1)
+
+	if watchers := service.Snapshot().Watchers; 2 != watchers {
+		t.Error("unexpected watchers:", watchers)
+	}
+
+	leave()
+
+	for _, expected := range []int{1, 2, 1} {
+		if event := <-events; EventWatchers != event.Type || expected != event.Game.Watchers {
+			t.Errorf("unexpected event: %s %d", event.Type, event.Game.Watchers)
+		}
+	}
+}
+
+func TestGameService_Resume(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+
+	_, _ = service.MakeMove(0, 0)
+	_, _ = service.MakeMove(1, 0)
+	_, _ = service.MakeMove(0, 1)
+	_, _ = service.MakeMove(1, 1)
+	_, _ = service.MakeMove(0, 2)
+
+	missed, _, unsubscribe := service.Resume(3)
+	unsubscribe()
+
+	if 3 != len(missed) {
+		t.Fatalf("unexpected missed events: %#v", missed)
+	}
+
+	if event := missed[0]; 4 != event.ID || "move" != event.Type || 1 != event.Move.X || 1 != event.Move.Y {
+		t.Errorf("unexpected event: %#v", event)
+	}
+
+	if event := missed[2]; 6 != event.ID || "win" != event.Type || 1 != event.Game.Winner {
+		t.Errorf("unexpected event: %#v", event)
+	}
+
+	if missed, _, _ := service.Resume(6); 0 != len(missed) {
+		t.Errorf("unexpected missed events: %#v", missed)
+	}
+
+	_, _ = service.Undo(0)
+
+	missed, events, _ := service.Resume(5)
+
+	if 1 != len(missed) || "reset" != missed[0].Type || 7 != missed[0].ID || 4 != missed[0].Game.NumMoves {
+		t.Errorf("unexpected missed events: %#v", missed)
+	}
+
+	_, _ = service.Redo(0)
+
+	if event := <-events; 8 != event.ID || "move" != event.Type {
+		t.Errorf("unexpected event: %#v", event)
+	}
+
+	if event := <-events; 9 != event.ID || "win" != event.Type {
+		t.Errorf("unexpected event: %#v", event)
+	}
+
+	if missed, _, _ := service.Resume(-1); 1 != len(missed) || "reset" != missed[0].Type || 9 != missed[0].ID {
+		t.Errorf("unexpected missed events: %#v", missed)
+	}
+}
+
+func TestGameService_Subscribe_SlowSubscriber(t *testing.T) {
+	game, _ := NewGame(19, 19, 19)
+	service := NewGameService(game)
+
+	events, _ := service.Subscribe()
+
+	for x := 0; x < 19; x++ {
+		_, _ = service.MakeMove(x, 0)
+	}
+
+	received := 0
+	for range events {
+		received++
+	}
+
+	if 16 != received {
+		t.Error("unexpected events:", received)
+	}
+}
+
+func TestGameService_Join(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+
+	if seat, err := service.Authorize(""); err != nil || 0 != seat {
+		t.Errorf("unexpected open seat: %d %v", seat, err)
+	}
+
+	seat, tokenO, err := service.Join(2)
+	if err != nil || 2 != seat || "" == tokenO {
+		t.Fatalf("unexpected join: %d %q %v", seat, tokenO, err)
+	}
+
+	seat, tokenX, err := service.Join(0)
+	if err != nil || 1 != seat || tokenO == tokenX {
+		t.Fatalf("unexpected join: %d %q %v", seat, tokenX, err)
+	}
+
+	if _, _, err := service.Join(0); err == nil || "no free seats" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, _, err := service.Join(1); err == nil || "seat taken: 1" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, _, err := service.Join(3); err == nil || "invalid seat: 3" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if seat, err := service.Authorize(tokenO); err != nil || 2 != seat {
+		t.Errorf("unexpected seat: %d %v", seat, err)
+	}
+
+	if _, err := service.Authorize(""); err == nil || "missing token" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := service.Authorize("nope"); err == nil || "invalid token" != err.Error() {
+		t.Error("unexpected err:", err)
+	}
+
+	if seats := service.Snapshot().Seats; 2 != len(seats) {
+		t.Error("unexpected seats:", seats)
+	}
+}
+
+func TestGameService_Seats_Restricted(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+	_, _, _ = service.Join(1)
+	_, _, _ = service.Join(2)
+
+	_, _ = service.MakeMoveAt(1, 1, 1, 0)
+
+	// neither may take back or replay a move while the opponent is seated
+	for _, player := range []int{1, 2} {
+		if _, err := service.Undo(player); ErrGameInProgress != errors.Cause(err) {
+			t.Errorf("%d> unexpected err: %v", player, err)
+		}
+
+		if _, err := service.Redo(player); ErrGameInProgress != errors.Cause(err) {
+			t.Errorf("%d> unexpected err: %v", player, err)
+		}
+	}
+
+	// neither may start the game over while it is being played
+	if _, err := service.Configure("", 4, 4, 3); ErrGameInProgress != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if snapshot := service.Snapshot(); 1 != snapshot.NumMoves || 3 != snapshot.Width() {
+		t.Errorf("unexpected game: %#v", snapshot)
+	}
+
+	for _, move := range [][2]int{{0, 1}, {0, 0}, {0, 2}, {2, 2}} {
+		_, _ = service.MakeMoveAt(0, move[0], move[1], 0)
+	}
+
+	if snapshot := service.Snapshot(); StatusEnd != snapshot.Status || 1 != snapshot.Winner {
+		t.Errorf("unexpected game: %#v", snapshot)
+	}
+
+	// once the game has ended only the player who made the move may take it
+	// back
+	if _, err := service.Undo(2); ErrWrongSeat != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := service.Configure("", 4, 4, 3); err != nil {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestGameService_Log(t *testing.T) {
+	game := &Game{}
+	game.Reset()
+
+	service := NewGameService(game)
+
+	for i := 0; i < 5; i++ {
+		_, _ = service.MakeMoveAt(0, 7, 7, 0)
+	}
+
+	_, _ = service.Reset()
+
+	// the log is only ever added to, a page at a time
+	if actions, total := service.Log(0, 4); 4 != len(actions) || 7 != total || ActionReset != actions[0].Type {
+		t.Errorf("unexpected log: %d %#v", total, actions)
+	}
+
+	if actions, total := service.Log(4, 4); 3 != len(actions) || 7 != total || ActionReset != actions[2].Type {
+		t.Errorf("unexpected log: %d %#v", total, actions)
+	}
+
+	if actions, _ := service.Log(10, 4); 0 != len(actions) {
+		t.Errorf("unexpected log: %#v", actions)
+	}
+}
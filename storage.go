@@ -0,0 +1,608 @@
+package main
+
+import (
+	"encoding/json"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+	"github.com/pkg/errors"
+)
+
+// Errors returned when creating a record which is already stored
+var (
+	ErrGameExists   = errors.New("game exists")
+	ErrPlayerExists = errors.New("player exists")
+)
+
+// Store persists games and tournaments by ID, and the players rated on them by
+// name, so they survive a restart.  Loading a game which is not stored returns
+// an error caused by ErrNotFound.  Player names are compared
+// case-insensitively.
+type Store interface {
+	Create(id string, record GameRecord) error
+	Load(id string) (GameRecord, error)
+	Save(id string, record GameRecord) error
+	List() ([]string, error)
+	Delete(id string) error
+
+	CreatePlayer(record PlayerRecord) error
+	SavePlayer(record PlayerRecord) error
+	ListPlayers() ([]PlayerRecord, error)
+
+	SaveTournament(record TournamentRecord) error
+	ListTournaments() ([]TournamentRecord, error)
+}
+
+// GameRecord is everything stored about a game, enough to carry on playing it
+// and streaming its events after a restart.
+type GameRecord struct {
+	Game       *Game       `json:"game"`
+	Bot        *BotRecord  `json:"bot,omitempty"`
+	Tokens     [3]string   `json:"tokens"`
+	Names      [3]string   `json:"names"`
+	Rated      bool        `json:"rated"`
+	Series     *Series     `json:"series,omitempty"`
+	Events     EventRecord `json:"events"`
+	LastActive time.Time   `json:"lastActive"`
+}
+
+// BotRecord is how the bot was set up
+type BotRecord struct {
+	Player      int      `json:"player"`
+	Difficulty  ai.Level `json:"difficulty"`
+	BlunderRate float64  `json:"blunderRate"`
+}
+
+// EventRecord holds the event IDs needed to resume event streams
+type EventRecord struct {
+	LastID   int   `json:"lastId"`
+	MoveIDs  []int `json:"moveIds"`
+	RewindID int   `json:"rewindId"`
+	EndID    int   `json:"endId"`
+}
+
+// PlayerRecord is a player account with its rating, results and how the
+// rating changed after every rated game.  The Key proves who the player is.
+type PlayerRecord struct {
+	Name    string         `json:"name"`
+	Key     string         `json:"key"`
+	Rating  int            `json:"rating"`
+	Wins    int            `json:"wins"`
+	Losses  int            `json:"losses"`
+	Draws   int            `json:"draws"`
+	History []RatingRecord `json:"history"`
+	Created time.Time      `json:"created"`
+}
+
+// RatingRecord is the outcome of a rated game for one of its players
+type RatingRecord struct {
+	GameID   string    `json:"gameId"`
+	Opponent string    `json:"opponent"`
+	Result   string    `json:"result"`
+	Rating   int       `json:"rating"`
+	Change   int       `json:"change"`
+	Time     time.Time `json:"time"`
+}
+
+// TournamentRecord is a tournament between the Players, listed by seed, with
+// the rounds paired so far.  Its games are played on the board given.
+type TournamentRecord struct {
+	ID        string            `json:"id"`
+	Name      string            `json:"name"`
+	Format    string            `json:"format"`
+	Players   []string          `json:"players"`
+	Width     int               `json:"width"`
+	Height    int               `json:"height"`
+	WinLength int               `json:"winLength"`
+	Rounds    [][]PairingRecord `json:"rounds"`
+	Status    string            `json:"status"`
+	Created   time.Time         `json:"created"`
+	Finished  time.Time         `json:"finished"`
+}
+
+// PairingRecord is a game of a tournament round between the players in each
+// seat, with the tokens to play it.  A bye has no second player and no game.
+// Elimination games which are drawn are played again, counted by Draws, and
+// once there have been too many the Tiebreak goes to the higher seed.
+type PairingRecord struct {
+	Players  [3]string `json:"players"`
+	GameID   string    `json:"gameId"`
+	Tokens   [3]string `json:"tokens"`
+	Done     bool      `json:"done"`
+	Winner   int       `json:"winner"`
+	Draws    int       `json:"draws"`
+	Tiebreak bool      `json:"tiebreak"`
+}
+
+// MemoryStore keeps the games in memory, it is lost on restart and meant for
+// tests.  Records are stored encoded so no memory is shared with the caller.
+type MemoryStore struct {
+	mu          sync.Mutex
+	records     map[string][]byte
+	players     map[string][]byte
+	tournaments map[string][]byte
+}
+
+// NewMemoryStore creates an empty store
+func NewMemoryStore() *MemoryStore {
+	return &MemoryStore{
+		records:     map[string][]byte{},
+		players:     map[string][]byte{},
+		tournaments: map[string][]byte{},
+	}
+}
+
+// Create stores a new game
+func (s *MemoryStore) Create(id string, record GameRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.records[id]; ok {
+		return errors.Wrapf(ErrGameExists, "failed to create game %s", id)
+	}
+
+	return s.save(id, record)
+}
+
+// Load returns the stored game
+func (s *MemoryStore) Load(id string) (GameRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	var record GameRecord
+
+	data, ok := s.records[id]
+	if !ok {
+		return record, newGameError(ErrNotFound, "game not found: %s", id)
+	}
+
+	return record, errors.Wrapf(json.Unmarshal(data, &record), "failed to decode game %s", id)
+}
+
+// Save replaces the stored game
+func (s *MemoryStore) Save(id string, record GameRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.save(id, record)
+}
+
+// save must be called with the lock held
+func (s *MemoryStore) save(id string, record GameRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode game %s", id)
+	}
+
+	s.records[id] = data
+
+	return nil
+}
+
+// List returns the IDs of the stored games in sorted order
+func (s *MemoryStore) List() ([]string, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.records))
+	for id := range s.records {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// Delete removes the game, deleting a game which is not stored does nothing
+func (s *MemoryStore) Delete(id string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.records, id)
+
+	return nil
+}
+
+// CreatePlayer stores a new player
+func (s *MemoryStore) CreatePlayer(record PlayerRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if _, ok := s.players[strings.ToLower(record.Name)]; ok {
+		return errors.Wrapf(ErrPlayerExists, "failed to create player %s", record.Name)
+	}
+
+	return s.savePlayer(record)
+}
+
+// SavePlayer replaces the stored player
+func (s *MemoryStore) SavePlayer(record PlayerRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	return s.savePlayer(record)
+}
+
+// savePlayer must be called with the lock held
+func (s *MemoryStore) savePlayer(record PlayerRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode player %s", record.Name)
+	}
+
+	s.players[strings.ToLower(record.Name)] = data
+
+	return nil
+}
+
+// ListPlayers returns every stored player sorted by name
+func (s *MemoryStore) ListPlayers() ([]PlayerRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := make([]string, 0, len(s.players))
+	for name := range s.players {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	records := make([]PlayerRecord, len(names))
+
+	for i, name := range names {
+		if err := json.Unmarshal(s.players[name], &records[i]); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode player %s", name)
+		}
+	}
+
+	return records, nil
+}
+
+// SaveTournament stores the tournament, replacing it if it was stored before
+func (s *MemoryStore) SaveTournament(record TournamentRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode tournament %s", record.ID)
+	}
+
+	s.tournaments[record.ID] = data
+
+	return nil
+}
+
+// ListTournaments returns every stored tournament sorted by ID
+func (s *MemoryStore) ListTournaments() ([]TournamentRecord, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	ids := make([]string, 0, len(s.tournaments))
+	for id := range s.tournaments {
+		ids = append(ids, id)
+	}
+
+	sort.Strings(ids)
+
+	records := make([]TournamentRecord, len(ids))
+
+	for i, id := range ids {
+		if err := json.Unmarshal(s.tournaments[id], &records[i]); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode tournament %s", id)
+		}
+	}
+
+	return records, nil
+}
+
+// FileStore keeps each game as a JSON document named after its ID in Dir,
+// each player named after them in its players directory and each tournament
+// named after its ID in its tournaments directory.  Documents are
+// replaced by renaming so a crash never leaves one half written.
+type FileStore struct {
+	Dir string
+}
+
+// gameFileExt is the extension of the game and player documents
+const gameFileExt = ".json"
+
+// Directories within Dir holding the players and tournaments
+const (
+	playersDir     = "players"
+	tournamentsDir = "tournaments"
+)
+
+// NewFileStore stores the games in dir, creating it if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	for _, sub := range []string{playersDir, tournamentsDir} {
+		if err := os.MkdirAll(filepath.Join(dir, sub), 0755); err != nil {
+			return nil, errors.Wrap(err, "failed to create data dir")
+		}
+	}
+
+	return &FileStore{Dir: dir}, nil
+}
+
+// Create stores a new game
+func (s *FileStore) Create(id string, record GameRecord) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return errors.Wrapf(ErrGameExists, "failed to create game %s", id)
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to create game %s", id)
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to create game %s", id)
+	}
+
+	return s.Save(id, record)
+}
+
+// Load returns the stored game
+func (s *FileStore) Load(id string) (GameRecord, error) {
+	var record GameRecord
+
+	path, err := s.path(id)
+	if err != nil {
+		return record, err
+	}
+
+	data, err := ioutil.ReadFile(path)
+	if os.IsNotExist(err) {
+		return record, newGameError(ErrNotFound, "game not found: %s", id)
+	}
+
+	if err != nil {
+		return record, errors.Wrapf(err, "failed to load game %s", id)
+	}
+
+	return record, errors.Wrapf(json.Unmarshal(data, &record), "failed to decode game %s", id)
+}
+
+// Save replaces the stored game
+func (s *FileStore) Save(id string, record GameRecord) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode game %s", id)
+	}
+
+	return errors.Wrapf(writeFile(path, data), "failed to save game %s", id)
+}
+
+// List returns the IDs of the stored games in sorted order
+func (s *FileStore) List() ([]string, error) {
+	files, err := ioutil.ReadDir(s.Dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list games")
+	}
+
+	ids := []string{}
+
+	for _, file := range files {
+		if id := strings.TrimSuffix(file.Name(), gameFileExt); !file.IsDir() && id != file.Name() && isValidGameID(id) {
+			ids = append(ids, id)
+		}
+	}
+
+	sort.Strings(ids)
+
+	return ids, nil
+}
+
+// Delete removes the game, deleting a game which is not stored does nothing
+func (s *FileStore) Delete(id string) error {
+	path, err := s.path(id)
+	if err != nil {
+		return err
+	}
+
+	if err := os.Remove(path); err != nil && !os.IsNotExist(err) {
+		return errors.Wrapf(err, "failed to delete game %s", id)
+	}
+
+	return nil
+}
+
+// CreatePlayer stores a new player
+func (s *FileStore) CreatePlayer(record PlayerRecord) error {
+	path, err := s.playerPath(record.Name)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.OpenFile(path, os.O_WRONLY|os.O_CREATE|os.O_EXCL, 0644)
+	if os.IsExist(err) {
+		return errors.Wrapf(ErrPlayerExists, "failed to create player %s", record.Name)
+	}
+
+	if err != nil {
+		return errors.Wrapf(err, "failed to create player %s", record.Name)
+	}
+
+	if err := file.Close(); err != nil {
+		return errors.Wrapf(err, "failed to create player %s", record.Name)
+	}
+
+	return s.SavePlayer(record)
+}
+
+// SavePlayer replaces the stored player
+func (s *FileStore) SavePlayer(record PlayerRecord) error {
+	path, err := s.playerPath(record.Name)
+	if err != nil {
+		return err
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode player %s", record.Name)
+	}
+
+	return errors.Wrapf(writeFile(path, data), "failed to save player %s", record.Name)
+}
+
+// ListPlayers returns every stored player sorted by name
+func (s *FileStore) ListPlayers() ([]PlayerRecord, error) {
+	dir := filepath.Join(s.Dir, playersDir)
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list players")
+	}
+
+	records := []PlayerRecord{}
+
+	for _, file := range files {
+		name := strings.TrimSuffix(file.Name(), gameFileExt)
+		if file.IsDir() || name == file.Name() || !isValidPlayerName(name) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load player %s", name)
+		}
+
+		var record PlayerRecord
+
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode player %s", name)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// SaveTournament stores the tournament, replacing it if it was stored before
+func (s *FileStore) SaveTournament(record TournamentRecord) error {
+	if !isValidGameID(record.ID) {
+		return newGameError(ErrNotFound, "tournament not found: %s", record.ID)
+	}
+
+	data, err := json.Marshal(record)
+	if err != nil {
+		return errors.Wrapf(err, "failed to encode tournament %s", record.ID)
+	}
+
+	path := filepath.Join(s.Dir, tournamentsDir, record.ID+gameFileExt)
+
+	return errors.Wrapf(writeFile(path, data), "failed to save tournament %s", record.ID)
+}
+
+// ListTournaments returns every stored tournament sorted by ID
+func (s *FileStore) ListTournaments() ([]TournamentRecord, error) {
+	dir := filepath.Join(s.Dir, tournamentsDir)
+
+	files, err := ioutil.ReadDir(dir)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to list tournaments")
+	}
+
+	records := []TournamentRecord{}
+
+	for _, file := range files {
+		id := strings.TrimSuffix(file.Name(), gameFileExt)
+		if file.IsDir() || id == file.Name() || !isValidGameID(id) {
+			continue
+		}
+
+		data, err := ioutil.ReadFile(filepath.Join(dir, file.Name()))
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to load tournament %s", id)
+		}
+
+		var record TournamentRecord
+
+		if err := json.Unmarshal(data, &record); err != nil {
+			return nil, errors.Wrapf(err, "failed to decode tournament %s", id)
+		}
+
+		records = append(records, record)
+	}
+
+	return records, nil
+}
+
+// playerPath is where the player is stored, refusing names which could
+// escape Dir
+func (s *FileStore) playerPath(name string) (string, error) {
+	if !isValidPlayerName(name) {
+		return "", newGameError(ErrInvalidSettings, "invalid name: %s", name)
+	}
+
+	return filepath.Join(s.Dir, playersDir, strings.ToLower(name)+gameFileExt), nil
+}
+
+// path is where the game is stored, refusing IDs which could escape Dir
+func (s *FileStore) path(id string) (string, error) {
+	if !isValidGameID(id) {
+		return "", newGameError(ErrNotFound, "game not found: %s", id)
+	}
+
+	return filepath.Join(s.Dir, id+gameFileExt), nil
+}
+
+// isValidGameID determines if the id could have been made by newGameID
+func isValidGameID(id string) bool {
+	if id == "" {
+		return false
+	}
+
+	for _, c := range id {
+		if (c < '0' || c > '9') && (c < 'a' || c > 'f') {
+			return false
+		}
+	}
+
+	return true
+}
+
+// writeFile replaces the file at path with data through a temporary file in
+// the same directory
+func writeFile(path string, data []byte) error {
+	file, err := ioutil.TempFile(filepath.Dir(path), filepath.Base(path)+".tmp")
+	if err != nil {
+		return err
+	}
+
+	if _, err := file.Write(data); err != nil {
+		file.Close()
+		os.Remove(file.Name())
+		return err
+	}
+
+	if err := file.Close(); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	if err := os.Rename(file.Name(), path); err != nil {
+		os.Remove(file.Name())
+		return err
+	}
+
+	return nil
+}
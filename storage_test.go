@@ -0,0 +1,147 @@
+package main
+
+import (
+	"io/ioutil"
+	"os"
+	"reflect"
+	"testing"
+
+	"github.com/pkg/errors"
+)
+
+func TestMemoryStore(t *testing.T) {
+	testStore(t, NewMemoryStore())
+}
+
+func TestFileStore(t *testing.T) {
+	dir, err := ioutil.TempDir("", "tick-dock-toe")
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+	defer os.RemoveAll(dir)
+
+	store, err := NewFileStore(dir)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	testStore(t, store)
+
+	if _, err := store.Load("../escape"); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := store.SavePlayer(PlayerRecord{Name: "../escape"}); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func testStore(t *testing.T, store Store) {
+	game := testNewGame()
+	_ = game.MakeMove(1, 1)
+
+	record := GameRecord{
+		Game:   game,
+		Bot:    &BotRecord{Player: 2, Difficulty: "perfect"},
+		Tokens: [3]string{"", "a1", ""},
+		Events: EventRecord{LastID: 1, MoveIDs: []int{1}},
+	}
+
+	if err := store.Create("ab12", record); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if err := store.Create("ab12", record); ErrGameExists != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	loaded, err := store.Load("ab12")
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if !reflect.DeepEqual(record.Game.Board, loaded.Game.Board) || 1 != len(loaded.Game.History) {
+		t.Errorf("unexpected game: %#v", loaded.Game)
+	}
+
+	if !reflect.DeepEqual(record.Bot, loaded.Bot) || record.Tokens != loaded.Tokens {
+		t.Errorf("unexpected record: %#v", loaded)
+	}
+
+	_ = game.MakeMove(0, 0)
+
+	if err := store.Save("ab12", record); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if loaded, _ := store.Load("ab12"); 2 != loaded.Game.NumMoves {
+		t.Error("unexpected numMoves:", loaded.Game.NumMoves)
+	}
+
+	_ = store.Create("0f", record)
+
+	if ids, err := store.List(); err != nil || !reflect.DeepEqual([]string{"0f", "ab12"}, ids) {
+		t.Errorf("unexpected ids: %#v %v", ids, err)
+	}
+
+	if err := store.Delete("ab12"); err != nil {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := store.Delete("ab12"); err != nil {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := store.Load("ab12"); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	player := PlayerRecord{Name: "Alice", Key: "k1", Rating: 1200}
+
+	if err := store.CreatePlayer(player); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if err := store.CreatePlayer(PlayerRecord{Name: "alice"}); ErrPlayerExists != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	player.Rating = 1216
+	player.History = []RatingRecord{{GameID: "ab12", Opponent: "bob", Result: ResultWin, Rating: 1216, Change: 16}}
+
+	if err := store.SavePlayer(player); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	_ = store.CreatePlayer(PlayerRecord{Name: "bob", Key: "k2", Rating: 1184})
+
+	players, err := store.ListPlayers()
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if 2 != len(players) || !reflect.DeepEqual(player, players[0]) || "bob" != players[1].Name {
+		t.Errorf("unexpected players: %#v", players)
+	}
+
+	tournament := TournamentRecord{
+		ID:      "cd34",
+		Format:  FormatRoundRobin,
+		Players: []string{"Alice", "bob"},
+		Rounds:  [][]PairingRecord{{{Players: [3]string{"", "Alice", "bob"}, GameID: "ab12"}}},
+	}
+
+	if err := store.SaveTournament(tournament); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	tournament.Rounds[0][0].Done = true
+
+	if err := store.SaveTournament(tournament); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if tournaments, err := store.ListTournaments(); err != nil || 1 != len(tournaments) || !reflect.DeepEqual(tournament, tournaments[0]) {
+		t.Errorf("unexpected tournaments: %#v %v", tournaments, err)
+	}
+}
@@ -0,0 +1,733 @@
+package main
+
+import (
+	"log"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+)
+
+// Tournament formats
+const (
+	FormatRoundRobin  = "round-robin"
+	FormatSwiss       = "swiss"
+	FormatElimination = "single-elimination"
+)
+
+// Tournament statuses
+const (
+	TournamentRunning  = "running"
+	TournamentFinished = "finished"
+)
+
+// Tournament limits, an elimination game drawn MaxReplays times goes to the
+// higher seed
+const (
+	MaxRoster  = 64
+	MaxReplays = 2
+)
+
+// Points scored for a result, a bye counts as a win
+const (
+	PointsWin  = 1.0
+	PointsDraw = 0.5
+)
+
+// Standing is a player's place in a tournament.  Players are ranked on their
+// points, then the Buchholz score (the points of everyone they played) and
+// then the Sonneborn-Berger score (the points of everyone they beat, half of
+// those they drew with).  In an elimination tournament the players who got
+// further come first.  Players who can't be told apart share the rank.
+type Standing struct {
+	Rank            int
+	Name            string
+	Seed            int
+	Points          float64
+	Wins            int
+	Draws           int
+	Losses          int
+	Byes            int
+	Buchholz        float64
+	SonnebornBerger float64
+	Reached         int
+	Eliminated      bool
+}
+
+// Tournaments runs the tournaments on the server.  Every game is created in
+// the Registry, which reports the result once it ends, and the next round is
+// paired as soon as the last game of a round has ended.  Every change is
+// saved to the Store.
+type Tournaments struct {
+	Registry *Registry
+	Store    Store
+
+	mu          sync.Mutex
+	tournaments map[string]*TournamentRecord
+
+	// games holds the tournament of each game still to be played
+	games map[string]*TournamentRecord
+}
+
+// NewTournaments creates an empty set of tournaments saved to the store
+func NewTournaments(registry *Registry, store Store) *Tournaments {
+	return &Tournaments{
+		Registry:    registry,
+		Store:       store,
+		tournaments: map[string]*TournamentRecord{},
+		games:       map[string]*TournamentRecord{},
+	}
+}
+
+// Load adds every tournament in the store and returns how many there were
+func (t *Tournaments) Load() (int, error) {
+	records, err := t.Store.ListTournaments()
+	if err != nil {
+		return 0, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := range records {
+		record := &records[i]
+		t.tournaments[record.ID] = record
+
+		for _, pairing := range record.round() {
+			if !pairing.Done && pairing.GameID != "" {
+				t.games[pairing.GameID] = record
+			}
+		}
+	}
+
+	return len(records), nil
+}
+
+// Create starts a tournament between the players, listed by seed, and pairs
+// its first round.  When players are rated they must have registered.
+func (t *Tournaments) Create(name, format string, players []string, width, height, winLength int) (TournamentRecord, error) {
+	switch format {
+	case FormatRoundRobin, FormatSwiss, FormatElimination:
+	default:
+		return TournamentRecord{}, newGameError(ErrInvalidSettings, "unknown format: %s", format)
+	}
+
+	if len(players) < 2 || len(players) > MaxRoster {
+		return TournamentRecord{}, newGameError(ErrInvalidSettings, "invalid number of players: %d", len(players))
+	}
+
+	roster := make([]string, len(players))
+	seen := map[string]bool{}
+
+	for i, player := range players {
+		if !isValidPlayerName(player) {
+			return TournamentRecord{}, newGameError(ErrInvalidSettings, "invalid name: %s", player)
+		}
+
+		if seen[strings.ToLower(player)] {
+			return TournamentRecord{}, newGameError(ErrInvalidSettings, "player listed twice: %s", player)
+		}
+
+		seen[strings.ToLower(player)] = true
+		roster[i] = player
+
+		if t.Registry.Ratings != nil {
+			record, err := t.Registry.Ratings.Player(player)
+			if err != nil {
+				return TournamentRecord{}, err
+			}
+
+			roster[i] = record.Name
+		}
+	}
+
+	// the board is checked, and its defaults filled in, by a game on it
+	game, err := NewGame(width, height, winLength)
+	if err != nil {
+		return TournamentRecord{}, err
+	}
+
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	var id string
+
+	for id == "" || t.tournaments[id] != nil {
+		if id, err = newGameID(); err != nil {
+			return TournamentRecord{}, errors.Wrap(err, "failed to generate tournament id")
+		}
+	}
+
+	record := &TournamentRecord{
+		ID:        id,
+		Name:      name,
+		Format:    format,
+		Players:   roster,
+		Width:     game.Width(),
+		Height:    game.Height(),
+		WinLength: game.WinLength,
+		Status:    TournamentRunning,
+		Created:   now(),
+	}
+
+	if err := t.advance(record); err != nil {
+		return TournamentRecord{}, err
+	}
+
+	t.tournaments[id] = record
+
+	return record.copy(), t.Store.SaveTournament(*record)
+}
+
+// Get returns the tournament with the given ID
+func (t *Tournaments) Get(id string) (TournamentRecord, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.tournaments[id]
+	if !ok {
+		return TournamentRecord{}, newGameError(ErrNotFound, "tournament not found: %s", id)
+	}
+
+	return record.copy(), nil
+}
+
+// List returns every tournament, the most recently created first
+func (t *Tournaments) List() []TournamentRecord {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	records := make([]TournamentRecord, 0, len(t.tournaments))
+	for _, record := range t.tournaments {
+		records = append(records, record.copy())
+	}
+
+	sort.Slice(records, func(i, j int) bool {
+		if !records[i].Created.Equal(records[j].Created) {
+			return records[i].Created.After(records[j].Created)
+		}
+
+		return records[i].ID < records[j].ID
+	})
+
+	return records
+}
+
+// Seat returns the game the player is to play next in the tournament, with
+// their seat and the token to play it.  A game evicted before it was played
+// is started again.
+func (t *Tournaments) Seat(id, name string) (string, int, string, error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.tournaments[id]
+	if !ok {
+		return "", 0, "", newGameError(ErrNotFound, "tournament not found: %s", id)
+	}
+
+	round := record.round()
+
+	for i := range round {
+		pairing := &round[i]
+
+		for seat := 1; seat <= 2; seat++ {
+			if pairing.Done || !strings.EqualFold(pairing.Players[seat], name) {
+				continue
+			}
+
+			if _, ok := t.Registry.Get(pairing.GameID); !ok {
+				delete(t.games, pairing.GameID)
+
+				if err := t.spawn(record, pairing); err != nil {
+					return "", 0, "", err
+				}
+
+				if err := t.Store.SaveTournament(*record); err != nil {
+					return "", 0, "", err
+				}
+			}
+
+			return pairing.GameID, seat, pairing.Tokens[seat], nil
+		}
+	}
+
+	return "", 0, "", newGameError(ErrNotFound, "no game to play for: %s", name)
+}
+
+// Report records how a game ended, pairing the next round once every game of
+// the round has.  Games which are not part of a tournament are ignored.
+func (t *Tournaments) Report(gameID string, result GameResult) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	record, ok := t.games[gameID]
+	if !ok {
+		return nil
+	}
+
+	delete(t.games, gameID)
+
+	round := record.round()
+
+	for i := range round {
+		pairing := &round[i]
+
+		if pairing.GameID != gameID || pairing.Done {
+			continue
+		}
+
+		if result.Winner == 0 && record.Format == FormatElimination {
+			if pairing.Draws < MaxReplays {
+				pairing.Draws++
+				pairing.Players = [3]string{"", pairing.Players[2], pairing.Players[1]}
+
+				if err := t.spawn(record, pairing); err != nil {
+					return err
+				}
+
+				return t.Store.SaveTournament(*record)
+			}
+
+			result.Winner = 1
+			if record.seed(pairing.Players[2]) < record.seed(pairing.Players[1]) {
+				result.Winner = 2
+			}
+
+			pairing.Tiebreak = true
+		}
+
+		pairing.Done = true
+		pairing.Winner = result.Winner
+	}
+
+	if err := t.advance(record); err != nil {
+		return err
+	}
+
+	return t.Store.SaveTournament(*record)
+}
+
+// advance pairs the next round, spawning its games, once every game of the
+// current one has ended.  The tournament is finished when there are no more
+// rounds to play.  It must be called with the lock held.
+func (t *Tournaments) advance(record *TournamentRecord) error {
+	for record.Status == TournamentRunning {
+		for _, pairing := range record.round() {
+			if !pairing.Done {
+				return nil
+			}
+		}
+
+		pairings := record.nextPairings()
+
+		if len(pairings) == 0 {
+			record.Status = TournamentFinished
+			record.Finished = now()
+
+			return nil
+		}
+
+		record.Rounds = append(record.Rounds, pairings)
+		round := record.round()
+
+		for i := range round {
+			if round[i].Players[2] == "" {
+				round[i].Done = true
+				round[i].Winner = 1
+				continue
+			}
+
+			if err := t.spawn(record, &round[i]); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
+
+// spawn creates the game for the pairing with both players seated, it must
+// be called with the lock held
+func (t *Tournaments) spawn(record *TournamentRecord, pairing *PairingRecord) error {
+	game, err := NewGame(record.Width, record.Height, record.WinLength)
+	if err != nil {
+		return err
+	}
+
+	id, service, err := t.Registry.Create(game)
+	if err != nil {
+		return err
+	}
+
+	for seat := 1; seat <= 2; seat++ {
+		if _, pairing.Tokens[seat], err = service.JoinAs(seat, pairing.Players[seat]); err != nil {
+			return err
+		}
+	}
+
+	t.Registry.Touch(id)
+
+	pairing.GameID = id
+	t.games[id] = record
+
+	log.Printf("[INFO] tournament %s: %s vs %s in %s\n", record.ID, pairing.Players[1], pairing.Players[2], id)
+
+	return nil
+}
+
+// round returns the pairings of the current round, which are shared with the
+// record
+func (r *TournamentRecord) round() []PairingRecord {
+	if len(r.Rounds) == 0 {
+		return nil
+	}
+
+	return r.Rounds[len(r.Rounds)-1]
+}
+
+// seed is the player's place in the roster, from 1
+func (r *TournamentRecord) seed(name string) int {
+	for i, player := range r.Players {
+		if player == name {
+			return i + 1
+		}
+	}
+
+	return 0
+}
+
+// nextPairings pairs the round after the last one, nothing once the
+// tournament has been played out
+func (r *TournamentRecord) nextPairings() []PairingRecord {
+	switch r.Format {
+	case FormatRoundRobin:
+		return r.roundRobinPairings()
+	case FormatSwiss:
+		return r.swissPairings()
+	case FormatElimination:
+		return r.eliminationPairings()
+	}
+
+	return nil
+}
+
+// roundRobinPairings uses the circle method, everyone bar the first player
+// moves round one place each round.  With an odd number of players whoever
+// meets the empty place has a bye.
+func (r *TournamentRecord) roundRobinPairings() []PairingRecord {
+	players := append([]string(nil), r.Players...)
+	if len(players)%2 == 1 {
+		players = append(players, "")
+	}
+
+	n := len(players)
+	round := len(r.Rounds)
+
+	if round == n-1 {
+		return nil
+	}
+
+	circle := make([]string, n)
+	circle[0] = players[0]
+
+	for i := 1; i < n; i++ {
+		circle[i] = players[1+(i-1+round)%(n-1)]
+	}
+
+	var pairings []PairingRecord
+
+	for i := 0; i < n/2; i++ {
+		x, o := circle[i], circle[n-1-i]
+
+		// the first player is X every other round, the others every other
+		// place round the circle
+		if (i == 0 && round%2 == 1) || i%2 == 1 {
+			x, o = o, x
+		}
+
+		pairings = append(pairings, newPairing(x, o))
+	}
+
+	return pairings
+}
+
+// swissPairings pairs the players in order of their standing with the next
+// player they haven't played yet, for as many rounds as it takes to find a
+// winner by elimination.  The lowest placed player who hasn't had a bye yet
+// has it, and whoever has been X less often is X.
+func (r *TournamentRecord) swissPairings() []PairingRecord {
+	rounds := 0
+	for 1<<uint(rounds) < len(r.Players) {
+		rounds++
+	}
+
+	if len(r.Rounds) == rounds {
+		return nil
+	}
+
+	played := map[[2]string]bool{}
+	crosses := map[string]int{}
+	byes := map[string]bool{}
+
+	for _, round := range r.Rounds {
+		for _, pairing := range round {
+			played[[2]string{pairing.Players[1], pairing.Players[2]}] = true
+			played[[2]string{pairing.Players[2], pairing.Players[1]}] = true
+			crosses[pairing.Players[1]]++
+
+			if pairing.Players[2] == "" {
+				byes[pairing.Players[1]] = true
+			}
+		}
+	}
+
+	var order []string
+	for _, standing := range r.Standings() {
+		order = append(order, standing.Name)
+	}
+
+	var bye []PairingRecord
+
+	if len(order)%2 == 1 {
+		i := len(order) - 1
+		for i > 0 && byes[order[i]] {
+			i--
+		}
+
+		bye = append(bye, newPairing(order[i], ""))
+		order = append(order[:i], order[i+1:]...)
+	}
+
+	var pairings []PairingRecord
+
+	for len(order) > 0 {
+		x := order[0]
+
+		j := 1
+		for j < len(order) && played[[2]string{x, order[j]}] {
+			j++
+		}
+
+		// everyone left has been played already
+		if j == len(order) {
+			j = 1
+		}
+
+		o := order[j]
+		order = append(order[1:j], order[j+1:]...)
+
+		if crosses[o] < crosses[x] {
+			x, o = o, x
+		}
+
+		pairings = append(pairings, newPairing(x, o))
+	}
+
+	return append(pairings, bye...)
+}
+
+// eliminationPairings pairs the winners of the last round, in the order of
+// the bracket, with the higher seed as X.  The first round seeds the bracket
+// so the top seeds only meet late and have the byes.
+func (r *TournamentRecord) eliminationPairings() []PairingRecord {
+	var players []string
+
+	if len(r.Rounds) == 0 {
+		size := 1
+		for size < len(r.Players) {
+			size *= 2
+		}
+
+		for _, seed := range bracketSeeds(size) {
+			player := ""
+			if seed <= len(r.Players) {
+				player = r.Players[seed-1]
+			}
+
+			players = append(players, player)
+		}
+	} else {
+		for _, pairing := range r.round() {
+			players = append(players, pairing.Players[pairing.Winner])
+		}
+	}
+
+	if len(players) < 2 {
+		return nil
+	}
+
+	var pairings []PairingRecord
+
+	for i := 0; i+1 < len(players); i += 2 {
+		x, o := players[i], players[i+1]
+
+		if x == "" || (o != "" && r.seed(o) < r.seed(x)) {
+			x, o = o, x
+		}
+
+		pairings = append(pairings, newPairing(x, o))
+	}
+
+	return pairings
+}
+
+// Standings ranks the players on the games played so far
+func (r *TournamentRecord) Standings() []Standing {
+	standings := make([]Standing, len(r.Players))
+	index := map[string]*Standing{}
+
+	for i, player := range r.Players {
+		standings[i] = Standing{Name: player, Seed: i + 1}
+		index[player] = &standings[i]
+	}
+
+	// scores holds what each player scored against each opponent
+	type score struct {
+		opponent string
+		points   float64
+	}
+
+	scores := map[string][]score{}
+
+	for _, round := range r.Rounds {
+		for _, pairing := range round {
+			for seat := 1; seat <= 2; seat++ {
+				if standing, ok := index[pairing.Players[seat]]; ok {
+					standing.Reached++
+				}
+			}
+
+			if !pairing.Done {
+				continue
+			}
+
+			x, o := index[pairing.Players[1]], index[pairing.Players[2]]
+
+			if o == nil {
+				x.Byes++
+				x.Points += PointsWin
+				continue
+			}
+
+			points := [3]float64{0, PointsDraw, PointsDraw}
+
+			if pairing.Tiebreak || pairing.Winner == 0 {
+				x.Draws++
+				o.Draws++
+			} else {
+				points = [3]float64{}
+				points[pairing.Winner] = PointsWin
+				winner, loser := index[pairing.Players[pairing.Winner]], index[pairing.Players[3-pairing.Winner]]
+				winner.Wins++
+				loser.Losses++
+			}
+
+			if r.Format == FormatElimination {
+				index[pairing.Players[3-pairing.Winner]].Eliminated = true
+			}
+
+			x.Points += points[1]
+			o.Points += points[2]
+
+			scores[x.Name] = append(scores[x.Name], score{o.Name, points[1]})
+			scores[o.Name] = append(scores[o.Name], score{x.Name, points[2]})
+		}
+	}
+
+	for i := range standings {
+		for _, s := range scores[standings[i].Name] {
+			standings[i].Buchholz += index[s.opponent].Points
+			standings[i].SonnebornBerger += index[s.opponent].Points * s.points
+		}
+	}
+
+	elimination := r.Format == FormatElimination
+
+	ahead := func(a, b *Standing) int {
+		switch {
+		case elimination && a.Reached != b.Reached:
+			return compareInts(a.Reached, b.Reached)
+		case elimination && a.Eliminated != b.Eliminated:
+			if b.Eliminated {
+				return 1
+			}
+			return -1
+		case a.Points != b.Points:
+			return compareFloats(a.Points, b.Points)
+		case a.Buchholz != b.Buchholz:
+			return compareFloats(a.Buchholz, b.Buchholz)
+		}
+
+		return compareFloats(a.SonnebornBerger, b.SonnebornBerger)
+	}
+
+	sort.SliceStable(standings, func(i, j int) bool {
+		return ahead(&standings[i], &standings[j]) > 0
+	})
+
+	for i := range standings {
+		standings[i].Rank = i + 1
+
+		if i > 0 && ahead(&standings[i-1], &standings[i]) == 0 {
+			standings[i].Rank = standings[i-1].Rank
+		}
+	}
+
+	return standings
+}
+
+// copy returns a copy of the record sharing no memory with it
+func (r *TournamentRecord) copy() TournamentRecord {
+	record := *r
+	record.Players = append([]string(nil), r.Players...)
+	record.Rounds = make([][]PairingRecord, len(r.Rounds))
+
+	for i, round := range r.Rounds {
+		record.Rounds[i] = append([]PairingRecord(nil), round...)
+	}
+
+	return record
+}
+
+func newPairing(x, o string) PairingRecord {
+	if x == "" {
+		x, o = o, x
+	}
+
+	return PairingRecord{Players: [3]string{"", x, o}}
+}
+
+// bracketSeeds orders the seeds so the best meet as late as possible, each
+// pair of seeds adding up to one more than the size of the bracket
+func bracketSeeds(size int) []int {
+	seeds := []int{1}
+
+	for len(seeds) < size {
+		n := len(seeds) * 2
+		next := make([]int, 0, n)
+
+		for _, seed := range seeds {
+			next = append(next, seed, n+1-seed)
+		}
+
+		seeds = next
+	}
+
+	return seeds
+}
+
+func compareInts(a, b int) int {
+	return compareFloats(float64(a), float64(b))
+}
+
+func compareFloats(a, b float64) int {
+	switch {
+	case a > b:
+		return 1
+	case a < b:
+		return -1
+	}
+
+	return 0
+}
@@ -0,0 +1,267 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+func testTournaments(t *testing.T, players ...string) *Tournaments {
+	store := NewMemoryStore()
+	ratings := NewRatings(store)
+
+	for _, player := range players {
+		if _, err := ratings.Register(player); err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+	}
+
+	registry := NewRegistry(time.Minute, store)
+	registry.Ratings = ratings
+
+	tournaments := NewTournaments(registry, store)
+	registry.Tournaments = tournaments
+
+	return tournaments
+}
+
+// testPlayRound plays every game of the tournament's current round, winner
+// picks the seat which wins each game with zero drawing it
+func testPlayRound(t *testing.T, tournaments *Tournaments, id string, winner func(pairing PairingRecord) int) {
+	record, _ := tournaments.Get(id)
+
+	for _, pairing := range record.round() {
+		if pairing.Done {
+			continue
+		}
+
+		service, ok := tournaments.Registry.Get(pairing.GameID)
+		if !ok {
+			t.Fatal("missing game:", pairing.GameID)
+		}
+
+		moves := [][2]int{{0, 0}, {1, 1}, {2, 2}, {0, 2}, {2, 0}, {1, 0}, {1, 2}, {2, 1}, {0, 1}}
+
+		switch winner(pairing) {
+		case 1:
+			moves = [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {0, 2}}
+		case 2:
+			moves = [][2]int{{0, 0}, {1, 0}, {0, 1}, {1, 1}, {2, 2}, {1, 2}}
+		}
+
+		for _, move := range moves {
+			if _, err := service.MakeMove(move[0], move[1]); err != nil {
+				t.Fatal("unexpected err:", err)
+			}
+		}
+
+		tournaments.Registry.Touch(pairing.GameID)
+	}
+}
+
+func TestTournaments_Create(t *testing.T) {
+	tournaments := testTournaments(t, "alice", "bob")
+
+	tests := []struct {
+		Format  string
+		Players []string
+		Err     error
+	}{
+		{"knockout", []string{"alice", "bob"}, ErrInvalidSettings},
+		{FormatSwiss, []string{"alice"}, ErrInvalidSettings},
+		{FormatSwiss, []string{"alice", "ALICE"}, ErrInvalidSettings},
+		{FormatSwiss, []string{"alice", "carol"}, ErrNotFound},
+	}
+
+	for i, test := range tests {
+		if _, err := tournaments.Create("", test.Format, test.Players, 0, 0, 0); test.Err != errors.Cause(err) {
+			t.Errorf("%d> unexpected err: %v", i, err)
+		}
+	}
+
+	record, err := tournaments.Create("office", FormatRoundRobin, []string{"Alice", "bob"}, 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if "alice" != record.Players[0] || 3 != record.Width || 1 != len(record.Rounds) {
+		t.Errorf("unexpected tournament: %#v", record)
+	}
+
+	gameID, seat, token, err := tournaments.Seat(record.ID, "bob")
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	service, _ := tournaments.Registry.Get(gameID)
+
+	if authorized, _ := service.Authorize(token); seat != authorized {
+		t.Error("unexpected seat:", authorized)
+	}
+
+	if _, _, _, err := tournaments.Seat(record.ID, "carol"); ErrNotFound != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	// the players can't start the game over, during or after it
+	if _, err := service.Configure("", 4, 4, 3); ErrGameInProgress != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	testPlayRound(t, tournaments, record.ID, func(PairingRecord) int { return 1 })
+
+	if _, err := service.Configure("", 4, 4, 3); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if _, err := service.Undo(seat); ErrGameOver != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+}
+
+func TestTournaments_RoundRobin(t *testing.T) {
+	players := []string{"alice", "bob", "carol"}
+	tournaments := testTournaments(t, players...)
+
+	record, _ := tournaments.Create("", FormatRoundRobin, players, 0, 0, 0)
+
+	// X wins every game
+	for i := 0; i < 3; i++ {
+		testPlayRound(t, tournaments, record.ID, func(PairingRecord) int {
+			return 1
+		})
+	}
+
+	record, _ = tournaments.Get(record.ID)
+
+	if TournamentFinished != record.Status || 3 != len(record.Rounds) {
+		t.Fatalf("unexpected tournament: %#v", record)
+	}
+
+	met := map[string]int{}
+
+	for _, round := range record.Rounds {
+		for _, pairing := range round {
+			met[pairing.Players[1]+"-"+pairing.Players[2]]++
+		}
+	}
+
+	if expected := map[string]int{"alice-": 1, "bob-": 1, "carol-": 1, "alice-carol": 1, "carol-bob": 1, "bob-alice": 1}; !reflect.DeepEqual(expected, met) {
+		t.Errorf("unexpected pairings: %#v", met)
+	}
+
+	// everyone won once, had a bye and lost once so they share the lead
+	for _, standing := range record.Standings() {
+		if 1 != standing.Rank || 2 != standing.Points || 1 != standing.Byes {
+			t.Errorf("unexpected standing: %#v", standing)
+		}
+	}
+
+	if alice, _ := tournaments.Registry.Ratings.Player("alice"); 1 != alice.Wins || 1 != alice.Losses {
+		t.Errorf("unexpected rating: %#v", alice)
+	}
+}
+
+func TestTournaments_Elimination(t *testing.T) {
+	players := []string{"alice", "bob", "carol", "dave", "erin"}
+	tournaments := testTournaments(t, players...)
+
+	record, _ := tournaments.Create("", FormatElimination, players, 0, 0, 0)
+
+	// the top three seeds have byes, dave plays erin
+	if round := record.round(); 4 != len(round) || [3]string{"", "dave", "erin"} != round[1].Players || !round[0].Done {
+		t.Fatalf("unexpected round: %#v", round)
+	}
+
+	// the lower seed wins
+	lower := func(pairing PairingRecord) int {
+		if record.seed(pairing.Players[1]) < record.seed(pairing.Players[2]) {
+			return 2
+		}
+		return 1
+	}
+
+	testPlayRound(t, tournaments, record.ID, lower)
+
+	record, _ = tournaments.Get(record.ID)
+
+	if round := record.round(); 2 != len(round) || [3]string{"", "alice", "erin"} != round[0].Players || [3]string{"", "bob", "carol"} != round[1].Players {
+		t.Fatalf("unexpected round: %#v", round)
+	}
+
+	// a drawn game is played again with the seats swapped, then goes to the
+	// higher seed
+	for i := 0; i <= MaxReplays; i++ {
+		testPlayRound(t, tournaments, record.ID, func(PairingRecord) int {
+			return 0
+		})
+	}
+
+	record, _ = tournaments.Get(record.ID)
+
+	if round := record.Rounds[1]; !round[0].Tiebreak || "alice" != round[0].Players[round[0].Winner] || MaxReplays != round[0].Draws {
+		t.Fatalf("unexpected round: %#v", round)
+	}
+
+	testPlayRound(t, tournaments, record.ID, lower)
+
+	record, _ = tournaments.Get(record.ID)
+
+	standings := record.Standings()
+
+	if TournamentFinished != record.Status || "bob" != standings[0].Name || "alice" != standings[1].Name || !standings[1].Eliminated {
+		t.Errorf("unexpected standings: %#v", standings)
+	}
+}
+
+func TestTournaments_Swiss(t *testing.T) {
+	players := []string{"alice", "bob", "carol", "dave"}
+	tournaments := testTournaments(t, players...)
+
+	record, _ := tournaments.Create("", FormatSwiss, players, 0, 0, 0)
+
+	// the higher seed wins
+	higher := func(pairing PairingRecord) int {
+		if record.seed(pairing.Players[1]) < record.seed(pairing.Players[2]) {
+			return 1
+		}
+		return 2
+	}
+
+	testPlayRound(t, tournaments, record.ID, higher)
+
+	record, _ = tournaments.Get(record.ID)
+
+	// the winners meet, as do the losers
+	if round := record.round(); 2 != len(round) || [3]string{"", "alice", "carol"} != round[0].Players || [3]string{"", "bob", "dave"} != round[1].Players {
+		t.Fatalf("unexpected round: %#v", round)
+	}
+
+	testPlayRound(t, tournaments, record.ID, higher)
+
+	record, _ = tournaments.Get(record.ID)
+
+	standings := record.Standings()
+
+	if TournamentFinished != record.Status || 2 != len(record.Rounds) {
+		t.Fatalf("unexpected tournament: %#v", record)
+	}
+
+	if "alice" != standings[0].Name || 2 != standings[0].Points || 2 != standings[0].Buchholz || 2 != standings[0].SonnebornBerger {
+		t.Errorf("unexpected standing: %#v", standings[0])
+	}
+
+	loaded := NewTournaments(tournaments.Registry, tournaments.Store)
+
+	if n, err := loaded.Load(); nil != err || 1 != n {
+		t.Error("unexpected loaded:", n, err)
+	}
+}
+
+func TestBracketSeeds(t *testing.T) {
+	if seeds := bracketSeeds(8); !reflect.DeepEqual([]int{1, 8, 4, 5, 2, 7, 3, 6}, seeds) {
+		t.Error("unexpected seeds:", seeds)
+	}
+}
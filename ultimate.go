@@ -0,0 +1,198 @@
+package main
+
+// Ultimate boards are SubBoardSize sub-boards across, each SubBoardSize cells
+// across, so UltimateSize cells across in all
+const (
+	SubBoardSize = 3
+	UltimateSize = SubBoardSize * SubBoardSize
+)
+
+// SubBoardDrawn marks a sub-board filled up without a winner, which counts for
+// neither player
+const SubBoardDrawn = 3
+
+// UltimateRules play VariantUltimate, the board is laid out as in a standard
+// game but moves are limited to the Active sub-boards and the game is won
+// across the SubBoards.  SubBoards holds who won each sub-board, so the
+// WinningLine runs across the sub-boards rather than the cells.
+type UltimateRules struct {
+	StandardRules
+}
+
+// Name is VariantUltimate
+func (UltimateRules) Name() string {
+	return VariantUltimate
+}
+
+// Setup only allows the ultimate board
+func (UltimateRules) Setup(width, height, winLength int) (Action, error) {
+	return fixedSetup(Action{Type: ActionReset, Variant: VariantUltimate, Width: UltimateSize, Height: UltimateSize, WinLength: SubBoardSize}, width, height, winLength)
+}
+
+// Start opens every sub-board
+func (r UltimateRules) Start(game *Game, reset Action) {
+	r.StandardRules.Start(game, reset)
+	r.update(game)
+}
+
+// Moves lists the empty cells of the active sub-boards
+func (r UltimateRules) Moves(game *Game) []Cell {
+	var cells []Cell
+
+	for _, cell := range r.StandardRules.Moves(game) {
+		if r.isActive(game, cell.X, cell.Y) {
+			cells = append(cells, cell)
+		}
+	}
+
+	return cells
+}
+
+// CheckMove also refuses cells outside the active sub-boards
+func (r UltimateRules) CheckMove(game *Game, cell Cell) error {
+	if err := r.StandardRules.CheckMove(game, cell); err != nil {
+		return err
+	}
+
+	if !r.isActive(game, cell.X, cell.Y) {
+		return newGameError(ErrInactiveBoard, "sub-board not in play: [%d][%d]", cell.X/SubBoardSize, cell.Y/SubBoardSize)
+	}
+
+	return nil
+}
+
+// Apply marks the cell and works out the sub-boards again
+func (r UltimateRules) Apply(game *Game, move Move) {
+	r.StandardRules.Apply(game, move)
+	r.update(game)
+}
+
+// Revert empties the cell and works out the sub-boards again
+func (r UltimateRules) Revert(game *Game, move Move) {
+	r.StandardRules.Revert(game, move)
+	r.update(game)
+}
+
+// Outcome runs the win checks across the sub-boards
+func (UltimateRules) Outcome(game *Game, player int) (Line, bool) {
+	return isWin(game.SubBoards, SubBoardSize, player)
+}
+
+// Terminal determines if every sub-board has been decided
+func (UltimateRules) Terminal(game *Game) bool {
+	return len(game.Active) == 0
+}
+
+// OrderMoves searches every move nearest the centre first, the active
+// sub-boards already narrow them down
+func (UltimateRules) OrderMoves(game *Game, moves []Cell) []Cell {
+	sortByCentre(game, moves)
+	return moves
+}
+
+// ultimateWeight is how much more a window of sub-boards is worth than one of
+// cells, roughly what it takes to win a sub-board
+const ultimateWeight = 64
+
+// Evaluate scores the windows across the sub-boards, which are worth far more,
+// along with the windows of the sub-boards still open
+func (UltimateRules) Evaluate(game *Game, player int) int {
+	score := scoreWindows(game.SubBoards, SubBoardSize, player) * ultimateWeight
+
+	for sx := range game.SubBoards {
+		for sy := range game.SubBoards[sx] {
+			if game.SubBoards[sx][sy] == 0 {
+				score += scoreWindows(game.subBoard(sx, sy), SubBoardSize, player)
+			}
+		}
+	}
+
+	return score
+}
+
+// SearchDepth is 4 moves, the game is too long to be searched to the end
+func (UltimateRules) SearchDepth(game *Game) int {
+	return 4
+}
+
+// isActive determines if x, y lies in a sub-board the next move may be made
+// in
+func (UltimateRules) isActive(game *Game, x, y int) bool {
+	for _, active := range game.Active {
+		if active.X == x/SubBoardSize && active.Y == y/SubBoardSize {
+			return true
+		}
+	}
+
+	return false
+}
+
+// update works out who holds each sub-board and where the next move may be
+// made from the board and the last move.  A move sends the opponent to the
+// sub-board matching the cell played, unless that sub-board is already
+// decided in which case they may play in any which is still open.  No
+// sub-board is active once the game is won.
+func (UltimateRules) update(g *Game) {
+	g.SubBoards = newBoard(SubBoardSize, SubBoardSize)
+
+	for sx := range g.SubBoards {
+		for sy := range g.SubBoards[sx] {
+			g.SubBoards[sx][sy] = g.subBoardWinner(sx, sy)
+		}
+	}
+
+	g.Active = nil
+
+	for player := 1; player <= 2; player++ {
+		if _, ok := isWin(g.SubBoards, SubBoardSize, player); ok {
+			return
+		}
+	}
+
+	if len(g.History) > 0 {
+		move := g.History[len(g.History)-1]
+		sx, sy := move.X%SubBoardSize, move.Y%SubBoardSize
+
+		if g.SubBoards[sx][sy] == 0 {
+			g.Active = []Cell{{X: sx, Y: sy}}
+			return
+		}
+	}
+
+	for sx := range g.SubBoards {
+		for sy := range g.SubBoards[sx] {
+			if g.SubBoards[sx][sy] == 0 {
+				g.Active = append(g.Active, Cell{X: sx, Y: sy})
+			}
+		}
+	}
+}
+
+// subBoardWinner is the player holding the sx, sy sub-board, SubBoardDrawn
+// once it is full without a winner and zero while it is still open
+func (g *Game) subBoardWinner(sx, sy int) int {
+	board := g.subBoard(sx, sy)
+
+	for player := 1; player <= 2; player++ {
+		if _, ok := isWin(board, SubBoardSize, player); ok {
+			return player
+		}
+	}
+
+	if isBoardFull(board) {
+		return SubBoardDrawn
+	}
+
+	return 0
+}
+
+// subBoard copies the cells of the sx, sy sub-board
+func (g *Game) subBoard(sx, sy int) [][]int {
+	board := newBoard(SubBoardSize, SubBoardSize)
+
+	for x := range board {
+		copy(board[x], g.Board[sx*SubBoardSize+x][sy*SubBoardSize:(sy+1)*SubBoardSize])
+	}
+
+	return board
+}
@@ -0,0 +1,167 @@
+package main
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/kris-runzer/tick-dock-toe/ai"
+	"github.com/pkg/errors"
+)
+
+func TestGame_Ultimate_Settings(t *testing.T) {
+	game, err := NewVariantGame(VariantUltimate, 0, 0, 0)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if UltimateSize != game.Width() || UltimateSize != game.Height() || SubBoardSize != game.WinLength || 9 != len(game.Active) {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if err := game.Configure(VariantUltimate, 15, 15, 5); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	if err := game.Configure("quantum", 0, 0, 0); ErrInvalidSettings != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	// switching back to standard takes the defaults
+	if err := game.Configure(VariantStandard, 0, 0, 0); err != nil || 3 != game.Width() || nil != game.SubBoards || nil != game.Active {
+		t.Errorf("unexpected game: %v %#v", err, game)
+	}
+}
+
+func TestGame_Ultimate_SubBoards(t *testing.T) {
+	game, _ := NewVariantGame(VariantUltimate, 0, 0, 0)
+
+	// the centre sends O to the centre sub-board
+	_ = game.MakeMove(4, 4)
+
+	if expected := []Cell{{X: 1, Y: 1}}; !reflect.DeepEqual(expected, game.Active) {
+		t.Errorf("unexpected active: %#v", game.Active)
+	}
+
+	if err := game.MakeMove(0, 0); ErrInactiveBoard != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	// X takes the top row of the corner sub-board while O keeps sending them
+	// back to it
+	for _, move := range [][2]int{{3, 3}, {1, 0}, {3, 0}, {2, 0}, {6, 0}, {0, 0}} {
+		if err := game.MakeMove(move[0], move[1]); err != nil {
+			t.Fatal("unexpected err:", err)
+		}
+	}
+
+	if 1 != game.SubBoards[0][0] || StatusAlive != game.Status {
+		t.Errorf("unexpected sub-boards: %#v", game.SubBoards)
+	}
+
+	// O is sent to a decided sub-board so may play in any of the others
+	if 8 != len(game.Active) {
+		t.Errorf("unexpected active: %#v", game.Active)
+	}
+
+	if err := game.MakeMove(1, 1); ErrInactiveBoard != errors.Cause(err) {
+		t.Error("unexpected err:", err)
+	}
+
+	_ = game.Undo()
+
+	if expected := []Cell{{X: 0, Y: 0}}; 0 != game.SubBoards[0][0] || !reflect.DeepEqual(expected, game.Active) {
+		t.Errorf("unexpected sub-boards: %#v %#v", game.SubBoards, game.Active)
+	}
+
+	if replayed := ReplayGame(game.Log); !reflect.DeepEqual(game.SubBoards, replayed.SubBoards) || !reflect.DeepEqual(game.Active, replayed.Active) {
+		t.Errorf("unexpected replay: %#v", replayed)
+	}
+}
+
+// testUltimateGame sets up X a move away from winning the top row of
+// sub-boards, with O having just sent them to the last of them
+func testUltimateGame() *Game {
+	game, _ := NewVariantGame(VariantUltimate, 0, 0, 0)
+
+	for _, cell := range []Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}, {X: 3, Y: 0}, {X: 4, Y: 0}, {X: 5, Y: 0}, {X: 6, Y: 0}, {X: 7, Y: 0}} {
+		game.Board[cell.X][cell.Y] = 1
+	}
+
+	for _, cell := range []Cell{{X: 0, Y: 3}, {X: 3, Y: 3}, {X: 4, Y: 4}, {X: 6, Y: 3}, {X: 6, Y: 4}, {X: 8, Y: 5}, {X: 7, Y: 8}, {X: 5, Y: 3}} {
+		game.Board[cell.X][cell.Y] = 2
+		game.History = append(game.History, Move{Player: 2, X: cell.X, Y: cell.Y})
+	}
+
+	UltimateRules{}.update(game)
+
+	return game
+}
+
+func TestGame_Ultimate_Win(t *testing.T) {
+	game := testUltimateGame()
+
+	if err := game.MakeMove(8, 0); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if StatusEnd != game.Status || 1 != game.Winner || nil != game.Active {
+		t.Errorf("unexpected game: %#v", game)
+	}
+
+	if expected := []Cell{{X: 0, Y: 0}, {X: 1, Y: 0}, {X: 2, Y: 0}}; nil == game.WinningLine || !reflect.DeepEqual(expected, game.WinningLine.Cells) {
+		t.Errorf("unexpected winning line: %#v", game.WinningLine)
+	}
+}
+
+func TestBotState_Ultimate(t *testing.T) {
+	game := testUltimateGame()
+
+	moves := (&botState{game: game}).Moves()
+
+	for _, move := range moves {
+		if move.X < 6 || move.Y > 2 {
+			t.Fatalf("unexpected move: %#v", move)
+		}
+	}
+
+	bot := ai.NewPlayer(ai.Perfect)
+	bot.MaxDepth = botDepth(game)
+
+	if move, err := bot.Move(&botState{game: game}); err != nil || (ai.Move{X: 8, Y: 0}) != move {
+		t.Error("unexpected move:", move, err)
+	}
+}
+
+func TestGameService_Bot_Ultimate(t *testing.T) {
+	game, _ := NewVariantGame(VariantUltimate, 0, 0, 0)
+	service := NewGameService(game)
+
+	started := time.Now()
+
+	// the bot opens with every sub-board open to it
+	snapshot, err := service.SetBot(ai.NewPlayer(ai.Perfect), 1)
+	if err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	// reply in the first empty cell of the sub-board O was sent to
+	active := snapshot.Active[0]
+	x, y := active.X*SubBoardSize, active.Y*SubBoardSize
+
+	for snapshot.Board[x][y] != 0 {
+		x++
+	}
+
+	if snapshot, err = service.MakeMove(x, y); err != nil {
+		t.Fatal("unexpected err:", err)
+	}
+
+	if numMoves := len(snapshot.History); 3 != numMoves {
+		t.Error("unexpected history:", numMoves)
+	}
+
+	if elapsed := time.Since(started); elapsed > 5*time.Second {
+		t.Error("unexpected elapsed:", elapsed)
+	}
+}
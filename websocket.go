@@ -0,0 +1,200 @@
+package main
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/websocket"
+)
+
+// WebSocket connection limits
+const (
+	wsWriteWait  = 10 * time.Second
+	wsPongWait   = 60 * time.Second
+	wsPingPeriod = wsPongWait * 9 / 10
+	wsMaxMessage = 1024
+)
+
+var upgrader = websocket.Upgrader{}
+
+// newWebSocketHandlerFunc pushes the state of the game to the client when it
+// connects and whenever it changes.  The client sends moves as MoveModels, a
+// refused move is answered with an ErrResponseModel while an accepted one is
+// pushed to every client like any other change.  Spectators only watch, every
+// move they send is refused.
+func newWebSocketHandlerFunc(registry *Registry, id string, service *GameService) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != MethodGet {
+			w.WriteHeader(http.StatusMethodNotAllowed)
+			return
+		}
+
+		spectating, err := spectate(r)
+		if err != nil {
+			jsonErrResponse(w, err)
+			return
+		}
+
+		// spectators connect without a token, or ignore theirs, and have
+		// their moves refused
+		token := bearerToken(r)
+		seat := 0
+
+		if spectating {
+			token = ""
+		} else if token != "" {
+			if seat, err = service.Authorize(token); err != nil {
+				jsonErrResponse(w, err)
+				return
+			}
+		}
+
+		conn, err := upgrader.Upgrade(w, r, nil)
+		if err != nil {
+			// the upgrader has already replied with the error
+			return
+		}
+		defer conn.Close()
+
+		// the player is disconnected once the last of their connections closes
+		defer service.Connect(seat)()
+
+		events, unsubscribe := service.Subscribe()
+		defer unsubscribe()
+
+		refusals := make(chan ErrResponseModel)
+		done := make(chan struct{})
+		closed := make(chan struct{})
+
+		defer close(done)
+
+		go func() {
+			defer close(closed)
+			readMoves(conn, registry, id, service, token, spectating, refusals, done)
+		}()
+
+		ticker := time.NewTicker(wsPingPeriod)
+		defer ticker.Stop()
+
+		if err := writeJSON(conn, newDefaultResponseModel(id, service.Snapshot())); err != nil {
+			return
+		}
+
+		for {
+			select {
+			case event, ok := <-events:
+				if !ok {
+					return
+				}
+
+				// the move which ended the game has already pushed its state
+				if event.Type == EventWin || event.Type == EventDraw {
+					continue
+				}
+
+				if err := writeJSON(conn, newDefaultResponseModel(id, event.Game)); err != nil {
+					return
+				}
+			case refusal := <-refusals:
+				if err := writeJSON(conn, refusal); err != nil {
+					return
+				}
+			case <-ticker.C:
+				_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+
+				if err := conn.WriteMessage(websocket.PingMessage, nil); err != nil {
+					return
+				}
+			case <-closed:
+				return
+			}
+		}
+	}
+}
+
+// readMoves makes the moves sent by the client, on behalf of the seat the
+// token holds, until the connection is closed.  Refused moves are handed back
+// to be written until done is closed.
+func readMoves(conn *websocket.Conn, registry *Registry, id string, service *GameService, token string, spectating bool, refusals chan<- ErrResponseModel, done <-chan struct{}) {
+	conn.SetReadLimit(wsMaxMessage)
+	_ = conn.SetReadDeadline(time.Now().Add(wsPongWait))
+
+	conn.SetPongHandler(func(string) error {
+		return conn.SetReadDeadline(time.Now().Add(wsPongWait))
+	})
+
+	for {
+		_, data, err := conn.ReadMessage()
+		if err != nil {
+			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure, websocket.CloseGoingAway) {
+				log.Printf("[ERROR] %s: %v\n", id, err)
+			}
+			return
+		}
+
+		err = newGameError(ErrWrongSeat, "spectators cannot move")
+
+		if !spectating {
+			err = readMove(registry, id, service, token, data)
+		}
+
+		if err != nil {
+			select {
+			case refusals <- newErrResponseModel(err):
+			case <-done:
+				return
+			}
+		}
+	}
+}
+
+// readMove makes the move in the message
+func readMove(registry *Registry, id string, service *GameService, token string, data []byte) error {
+	var model MoveModel
+
+	if err := json.Unmarshal(data, &model); err != nil {
+		return newGameError(ErrBadRequest, "malformed move: %v", err)
+	}
+
+	seat, err := service.Authorize(token)
+	if err != nil {
+		return err
+	}
+
+	if _, err := makeMove(service, seat, model); err != nil {
+		// refused moves are logged, saved as over HTTP without keeping the
+		// game from being evicted
+		registry.Save(id)
+
+		return err
+	}
+
+	registry.Touch(id)
+
+	return nil
+}
+
+// spectate determines if the client asked to only watch the game with the
+// spectate query parameter
+func spectate(r *http.Request) (bool, error) {
+	value := r.URL.Query().Get("spectate")
+	if value == "" {
+		return false, nil
+	}
+
+	spectating, err := strconv.ParseBool(value)
+	if err != nil {
+		return false, newGameError(ErrBadRequest, "malformed spectate: %s", value)
+	}
+
+	return spectating, nil
+}
+
+func writeJSON(conn *websocket.Conn, v interface{}) error {
+	_ = conn.SetWriteDeadline(time.Now().Add(wsWriteWait))
+
+	return conn.WriteJSON(v)
+}